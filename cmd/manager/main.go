@@ -20,6 +20,7 @@ import (
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/rdbc-operator/pkg/apis"
 	"github.com/rdbc-operator/pkg/controller"
+	"github.com/rdbc-operator/pkg/webhook"
 	"github.com/spf13/pflag"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -116,6 +117,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup the admission webhooks
+	if err := webhook.AddToManager(mgr); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	if err = serveCRMetrics(cfg); err != nil {
 		log.Info("Could not generate and serve custom resource metrics", "error", err.Error())
 	}
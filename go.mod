@@ -2,8 +2,9 @@ module github.com/rdbc-operator
 
 require (
 	github.com/go-openapi/spec v0.19.0
-	github.com/google/uuid v1.1.1
+	github.com/google/uuid v1.1.1 // indirect
 	github.com/operator-framework/operator-sdk v0.10.1-0.20190911145116-334c667503d0
+	github.com/prometheus/client_golang v0.9.3-0.20190127221311-3c4408c8b829
 	github.com/spf13/pflag v1.0.3
 	k8s.io/api v0.0.0-20190612125737-db0771252981
 	k8s.io/apimachinery v0.0.0-20190612125636-6a5db36e93ad
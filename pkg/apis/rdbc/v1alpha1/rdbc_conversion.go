@@ -0,0 +1,241 @@
+package v1alpha1
+
+import (
+	"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+)
+
+// The v1beta1 Rdbc is the storage version, the v1alpha1 Rdbc is converted to it and back:
+// - the plaintext spec.password is kept in the v1beta1.PasswordAnnotation until the operator moves it to a Secret
+// - the status.message is derived from the Degraded or Ready condition
+
+// Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc converts the v1alpha1 Rdbc to the storage version
+func Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc(in *Rdbc, out *v1beta1.Rdbc) error {
+	in = in.DeepCopy()
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = in.ObjectMeta
+	out.APIVersion = v1beta1.SchemeGroupVersion.String()
+	if in.Spec.Password != "" {
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[v1beta1.PasswordAnnotation] = in.Spec.Password
+	}
+	convertRdbcSpecToV1beta1(&in.Spec, &out.Spec)
+	convertRdbcStatusToV1beta1(&in.Status, &out.Status)
+	return nil
+}
+
+// Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc converts the storage version Rdbc to v1alpha1
+func Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc(in *v1beta1.Rdbc, out *Rdbc) error {
+	in = in.DeepCopy()
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = in.ObjectMeta
+	out.APIVersion = SchemeGroupVersion.String()
+	if password, ok := in.Annotations[v1beta1.PasswordAnnotation]; ok {
+		out.Spec.Password = password
+		delete(out.Annotations, v1beta1.PasswordAnnotation)
+		if len(out.Annotations) == 0 {
+			out.Annotations = nil
+		}
+	}
+	convertRdbcSpecFromV1beta1(&in.Spec, &out.Spec)
+	convertRdbcStatusFromV1beta1(&in.Status, &out.Status)
+	return nil
+}
+
+func convertRdbcSpecToV1beta1(in *RdbcSpec, out *v1beta1.RdbcSpec) {
+	out.Name = in.Name
+	out.Size = in.Size
+	out.PasswordSecretRef = in.PasswordSecretRef
+	if in.PasswordPolicy != nil {
+		out.PasswordPolicy = &v1beta1.PasswordPolicy{Length: in.PasswordPolicy.Length, Charset: in.PasswordPolicy.Charset}
+	}
+	if in.PasswordRotation != nil {
+		out.PasswordRotation = &v1beta1.PasswordRotation{Interval: in.PasswordRotation.Interval}
+	}
+	out.Replication = in.Replication
+	out.ShardsCount = in.ShardsCount
+	out.ShardKeyRegex = in.ShardKeyRegex
+	out.OSSCluster = in.OSSCluster
+	out.ProxyPolicy = in.ProxyPolicy
+	out.DataPersistence = in.DataPersistence
+	out.AofPolicy = in.AofPolicy
+	out.SnapshotPolicy = nil
+	for _, policy := range in.SnapshotPolicy {
+		out.SnapshotPolicy = append(out.SnapshotPolicy, v1beta1.SnapshotPolicy{Secs: policy.Secs, Writes: policy.Writes})
+	}
+	out.EvictionPolicy = in.EvictionPolicy
+	out.Modules = nil
+	for _, module := range in.Modules {
+		out.Modules = append(out.Modules, v1beta1.RdbcModule{Name: module.Name, Version: module.Version, Args: module.Args})
+	}
+	out.ClusterRef = in.ClusterRef
+	out.DeletionPolicy = in.DeletionPolicy
+	out.DriftPolicy = in.DriftPolicy
+	if in.AdoptFrom != nil {
+		out.AdoptFrom = &v1beta1.AdoptFrom{Uid: in.AdoptFrom.Uid, Name: in.AdoptFrom.Name, DryRun: in.AdoptFrom.DryRun}
+	}
+}
+
+func convertRdbcSpecFromV1beta1(in *v1beta1.RdbcSpec, out *RdbcSpec) {
+	out.Name = in.Name
+	out.Size = in.Size
+	out.PasswordSecretRef = in.PasswordSecretRef
+	if in.PasswordPolicy != nil {
+		out.PasswordPolicy = &PasswordPolicy{Length: in.PasswordPolicy.Length, Charset: in.PasswordPolicy.Charset}
+	}
+	if in.PasswordRotation != nil {
+		out.PasswordRotation = &PasswordRotation{Interval: in.PasswordRotation.Interval}
+	}
+	out.Replication = in.Replication
+	out.ShardsCount = in.ShardsCount
+	out.ShardKeyRegex = in.ShardKeyRegex
+	out.OSSCluster = in.OSSCluster
+	out.ProxyPolicy = in.ProxyPolicy
+	out.DataPersistence = in.DataPersistence
+	out.AofPolicy = in.AofPolicy
+	out.SnapshotPolicy = nil
+	for _, policy := range in.SnapshotPolicy {
+		out.SnapshotPolicy = append(out.SnapshotPolicy, SnapshotPolicy{Secs: policy.Secs, Writes: policy.Writes})
+	}
+	out.EvictionPolicy = in.EvictionPolicy
+	out.Modules = nil
+	for _, module := range in.Modules {
+		out.Modules = append(out.Modules, RdbcModule{Name: module.Name, Version: module.Version, Args: module.Args})
+	}
+	out.ClusterRef = in.ClusterRef
+	out.DeletionPolicy = in.DeletionPolicy
+	out.DriftPolicy = in.DriftPolicy
+	if in.AdoptFrom != nil {
+		out.AdoptFrom = &AdoptFrom{Uid: in.AdoptFrom.Uid, Name: in.AdoptFrom.Name, DryRun: in.AdoptFrom.DryRun}
+	}
+}
+
+func convertRdbcStatusToV1beta1(in *RdbcStatus, out *v1beta1.RdbcStatus) {
+	out.Phase = v1beta1.RdbcPhase(in.Phase)
+	out.Conditions = nil
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, v1beta1.RdbcCondition{
+			Type:               v1beta1.RdbcConditionType(c.Type),
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	out.ObservedGeneration = in.ObservedGeneration
+	out.DbUid = in.DbUid
+	out.Endpoint = in.Endpoint
+	out.LastSyncTime = in.LastSyncTime
+	out.MemorySize = in.MemorySize
+	out.PendingAction = in.PendingAction
+	if in.Persistence != nil {
+		out.Persistence = &v1beta1.RdbcPersistenceStatus{
+			DataPersistence: in.Persistence.DataPersistence,
+			AofPolicy:       in.Persistence.AofPolicy,
+			EvictionPolicy:  in.Persistence.EvictionPolicy,
+		}
+		for _, policy := range in.Persistence.SnapshotPolicy {
+			out.Persistence.SnapshotPolicy = append(out.Persistence.SnapshotPolicy, v1beta1.SnapshotPolicy{Secs: policy.Secs, Writes: policy.Writes})
+		}
+	}
+	out.LastPasswordRotation = in.LastPasswordRotation
+	out.Cluster = in.Cluster
+	if in.Adoption != nil {
+		out.Adoption = &v1beta1.RdbcAdoptionStatus{
+			DbUid:    in.Adoption.DbUid,
+			DbName:   in.Adoption.DbName,
+			Adopted:  in.Adoption.Adopted,
+			Changes:  in.Adoption.Changes,
+			Rejected: in.Adoption.Rejected,
+		}
+	}
+	if in.DeletionSnapshot != nil {
+		out.DeletionSnapshot = &v1beta1.RdbcSnapshotStatus{
+			ActionUid:      in.DeletionSnapshot.ActionUid,
+			Location:       in.DeletionSnapshot.Location,
+			StartTime:      in.DeletionSnapshot.StartTime,
+			CompletionTime: in.DeletionSnapshot.CompletionTime,
+		}
+	}
+	if in.Usage != nil {
+		out.Usage = &v1beta1.RdbcUsageStatus{
+			UsedMemory:       in.Usage.UsedMemory,
+			OpsPerSec:        in.Usage.OpsPerSec,
+			ConnectedClients: in.Usage.ConnectedClients,
+			EvictionsPerSec:  in.Usage.EvictionsPerSec,
+			LastUpdateTime:   in.Usage.LastUpdateTime,
+		}
+	}
+}
+
+func convertRdbcStatusFromV1beta1(in *v1beta1.RdbcStatus, out *RdbcStatus) {
+	out.Message = rdbcStatusMessage(in)
+	out.Phase = RdbcPhase(in.Phase)
+	out.Conditions = nil
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, RdbcCondition{
+			Type:               RdbcConditionType(c.Type),
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	out.ObservedGeneration = in.ObservedGeneration
+	out.DbUid = in.DbUid
+	out.Endpoint = in.Endpoint
+	out.LastSyncTime = in.LastSyncTime
+	out.MemorySize = in.MemorySize
+	out.PendingAction = in.PendingAction
+	if in.Persistence != nil {
+		out.Persistence = &RdbcPersistenceStatus{
+			DataPersistence: in.Persistence.DataPersistence,
+			AofPolicy:       in.Persistence.AofPolicy,
+			EvictionPolicy:  in.Persistence.EvictionPolicy,
+		}
+		for _, policy := range in.Persistence.SnapshotPolicy {
+			out.Persistence.SnapshotPolicy = append(out.Persistence.SnapshotPolicy, SnapshotPolicy{Secs: policy.Secs, Writes: policy.Writes})
+		}
+	}
+	out.LastPasswordRotation = in.LastPasswordRotation
+	out.Cluster = in.Cluster
+	if in.Adoption != nil {
+		out.Adoption = &RdbcAdoptionStatus{
+			DbUid:    in.Adoption.DbUid,
+			DbName:   in.Adoption.DbName,
+			Adopted:  in.Adoption.Adopted,
+			Changes:  in.Adoption.Changes,
+			Rejected: in.Adoption.Rejected,
+		}
+	}
+	if in.DeletionSnapshot != nil {
+		out.DeletionSnapshot = &RdbcSnapshotStatus{
+			ActionUid:      in.DeletionSnapshot.ActionUid,
+			Location:       in.DeletionSnapshot.Location,
+			StartTime:      in.DeletionSnapshot.StartTime,
+			CompletionTime: in.DeletionSnapshot.CompletionTime,
+		}
+	}
+	if in.Usage != nil {
+		out.Usage = &RdbcUsageStatus{
+			UsedMemory:       in.Usage.UsedMemory,
+			OpsPerSec:        in.Usage.OpsPerSec,
+			ConnectedClients: in.Usage.ConnectedClients,
+			EvictionsPerSec:  in.Usage.EvictionsPerSec,
+			LastUpdateTime:   in.Usage.LastUpdateTime,
+		}
+	}
+}
+
+// rdbcStatusMessage returns the message of the Degraded condition if the Rdbc is degraded,
+// otherwise the message of the Ready condition
+func rdbcStatusMessage(status *v1beta1.RdbcStatus) string {
+	if status.IsConditionTrue(v1beta1.RdbcConditionDegraded) {
+		return status.GetCondition(v1beta1.RdbcConditionDegraded).Message
+	}
+	if c := status.GetCondition(v1beta1.RdbcConditionReady); c != nil {
+		return c.Message
+	}
+	return ""
+}
@@ -0,0 +1,258 @@
+package v1alpha1
+
+import (
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+)
+
+// semantic treats the nil and empty slices and maps as equal, they serialize the same with omitempty
+var semantic = conversion.EqualitiesOrDie(
+	func(a, b metav1.Time) bool {
+		return a.UTC() == b.UTC()
+	},
+	func(a, b resource.Quantity) bool {
+		return a.Cmp(b) == 0
+	},
+)
+
+// newFuzzer fills every field, the condition types and statuses are picked from the known ones
+// for the status.message derivation to be exercised
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	conditionTypes := []RdbcConditionType{RdbcConditionReady, RdbcConditionDegraded, RdbcConditionProvisioning}
+	conditionStatuses := []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown}
+	return fuzz.New().RandSource(rand.NewSource(seed)).NilChance(0.2).NumElements(0, 3).Funcs(
+		func(t *RdbcConditionType, c fuzz.Continue) {
+			*t = conditionTypes[c.Intn(len(conditionTypes))]
+		},
+		func(t *v1beta1.RdbcConditionType, c fuzz.Continue) {
+			*t = v1beta1.RdbcConditionType(conditionTypes[c.Intn(len(conditionTypes))])
+		},
+		func(s *corev1.ConditionStatus, c fuzz.Continue) {
+			*s = conditionStatuses[c.Intn(len(conditionStatuses))]
+		},
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewScaledQuantity(c.Int63n(1000000000), resource.Micro)
+		},
+	)
+}
+
+func TestRdbcRoundTripFuzz(t *testing.T) {
+	f := newFuzzer(1)
+	for i := 0; i < 1000; i++ {
+		in := &Rdbc{}
+		f.Fuzz(in)
+		in.TypeMeta = metav1.TypeMeta{Kind: "Rdbc", APIVersion: SchemeGroupVersion.String()}
+		delete(in.Annotations, v1beta1.PasswordAnnotation)
+
+		beta := &v1beta1.Rdbc{}
+		if err := Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc(in, beta); err != nil {
+			t.Fatalf("Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc() error = %v", err)
+		}
+		// The status.message isn't stored, it's derived from the conditions
+		in.Status.Message = rdbcStatusMessage(&beta.Status)
+
+		out := &Rdbc{}
+		if err := Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc(beta, out); err != nil {
+			t.Fatalf("Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc() error = %v", err)
+		}
+		if !semantic.DeepEqual(in, out) {
+			t.Fatalf("round trip of v1alpha1 Rdbc failed:\n  in: %+v\n out: %+v", in, out)
+		}
+	}
+}
+
+func TestRdbcStorageRoundTripFuzz(t *testing.T) {
+	f := newFuzzer(2)
+	for i := 0; i < 1000; i++ {
+		in := &v1beta1.Rdbc{}
+		f.Fuzz(in)
+		in.TypeMeta = metav1.TypeMeta{Kind: "Rdbc", APIVersion: v1beta1.SchemeGroupVersion.String()}
+		// An empty password annotation is dropped, v1alpha1 has no unset password
+		if in.Annotations[v1beta1.PasswordAnnotation] == "" {
+			delete(in.Annotations, v1beta1.PasswordAnnotation)
+		}
+
+		alpha := &Rdbc{}
+		if err := Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc(in, alpha); err != nil {
+			t.Fatalf("Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc() error = %v", err)
+		}
+		out := &v1beta1.Rdbc{}
+		if err := Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc(alpha, out); err != nil {
+			t.Fatalf("Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc() error = %v", err)
+		}
+		if !semantic.DeepEqual(in, out) {
+			t.Fatalf("round trip of v1beta1 Rdbc failed:\n  in: %+v\n out: %+v", in, out)
+		}
+	}
+}
+
+func TestRdbcConversion(t *testing.T) {
+	tests := []struct {
+		name  string
+		alpha *Rdbc
+		beta  *v1beta1.Rdbc
+	}{
+		{
+			name:  "empty",
+			alpha: &Rdbc{},
+			beta:  &v1beta1.Rdbc{},
+		},
+		{
+			name: "password moved to the annotation",
+			alpha: &Rdbc{
+				ObjectMeta: metav1.ObjectMeta{Name: "db"},
+				Spec:       RdbcSpec{Name: "db", Password: "secret"},
+			},
+			beta: &v1beta1.Rdbc{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Annotations: map[string]string{v1beta1.PasswordAnnotation: "secret"}},
+				Spec:       v1beta1.RdbcSpec{Name: "db"},
+			},
+		},
+		{
+			name: "password annotation next to other annotations",
+			alpha: &Rdbc{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "cache"}},
+				Spec:       RdbcSpec{Password: "secret"},
+			},
+			beta: &v1beta1.Rdbc{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "cache", v1beta1.PasswordAnnotation: "secret"}},
+			},
+		},
+		{
+			name: "slices and optional structs",
+			alpha: &Rdbc{
+				Spec: RdbcSpec{
+					SnapshotPolicy: []SnapshotPolicy{{Secs: 3600, Writes: 1}},
+					Modules:        []RdbcModule{{Name: "search", Version: "2.0", Args: "MAXDOCTABLESIZE 1000"}},
+					PasswordPolicy: &PasswordPolicy{Length: 32, Charset: "alphanumeric"},
+					AdoptFrom:      &AdoptFrom{Uid: 3, DryRun: true},
+				},
+				Status: RdbcStatus{
+					Persistence: &RdbcPersistenceStatus{DataPersistence: "snapshot", SnapshotPolicy: []SnapshotPolicy{{Secs: 60, Writes: 10}}},
+					Adoption:    &RdbcAdoptionStatus{DbUid: 3, Changes: []string{"size"}, Rejected: []string{"name"}},
+				},
+			},
+			beta: &v1beta1.Rdbc{
+				Spec: v1beta1.RdbcSpec{
+					SnapshotPolicy: []v1beta1.SnapshotPolicy{{Secs: 3600, Writes: 1}},
+					Modules:        []v1beta1.RdbcModule{{Name: "search", Version: "2.0", Args: "MAXDOCTABLESIZE 1000"}},
+					PasswordPolicy: &v1beta1.PasswordPolicy{Length: 32, Charset: "alphanumeric"},
+					AdoptFrom:      &v1beta1.AdoptFrom{Uid: 3, DryRun: true},
+				},
+				Status: v1beta1.RdbcStatus{
+					Persistence: &v1beta1.RdbcPersistenceStatus{DataPersistence: "snapshot", SnapshotPolicy: []v1beta1.SnapshotPolicy{{Secs: 60, Writes: 10}}},
+					Adoption:    &v1beta1.RdbcAdoptionStatus{DbUid: 3, Changes: []string{"size"}, Rejected: []string{"name"}},
+				},
+			},
+		},
+		{
+			name: "empty slices",
+			alpha: &Rdbc{
+				Spec:   RdbcSpec{SnapshotPolicy: []SnapshotPolicy{}, Modules: []RdbcModule{}},
+				Status: RdbcStatus{Conditions: []RdbcCondition{}},
+			},
+			beta: &v1beta1.Rdbc{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beta := &v1beta1.Rdbc{}
+			if err := Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc(tt.alpha, beta); err != nil {
+				t.Fatalf("Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc() error = %v", err)
+			}
+			tt.beta.APIVersion = v1beta1.SchemeGroupVersion.String()
+			if !semantic.DeepEqual(tt.beta, beta) {
+				t.Errorf("Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc() = %+v, want %+v", beta, tt.beta)
+			}
+
+			alpha := &Rdbc{}
+			if err := Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc(tt.beta, alpha); err != nil {
+				t.Fatalf("Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc() error = %v", err)
+			}
+			want := tt.alpha.DeepCopy()
+			want.APIVersion = SchemeGroupVersion.String()
+			if !semantic.DeepEqual(want, alpha) {
+				t.Errorf("Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc() = %+v, want %+v", alpha, want)
+			}
+			if _, ok := alpha.Annotations[v1beta1.PasswordAnnotation]; ok {
+				t.Errorf("the v1alpha1 Rdbc keeps the %s annotation", v1beta1.PasswordAnnotation)
+			}
+		})
+	}
+}
+
+func TestRdbcConversionDoesNotShareInput(t *testing.T) {
+	in := &Rdbc{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "cache"}},
+		Spec:       RdbcSpec{Password: "secret", SnapshotPolicy: []SnapshotPolicy{{Secs: 60, Writes: 1}}},
+	}
+	out := &v1beta1.Rdbc{}
+	if err := Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc(in, out); err != nil {
+		t.Fatalf("Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc() error = %v", err)
+	}
+	if _, ok := in.Annotations[v1beta1.PasswordAnnotation]; ok {
+		t.Errorf("the password annotation is added to the input annotations")
+	}
+	out.Spec.SnapshotPolicy[0].Secs = 1
+	if in.Spec.SnapshotPolicy[0].Secs != 60 {
+		t.Errorf("the converted snapshot policy shares the input slice")
+	}
+}
+
+func TestRdbcStatusMessage(t *testing.T) {
+	condition := func(conditionType v1beta1.RdbcConditionType, status corev1.ConditionStatus, message string) v1beta1.RdbcCondition {
+		return v1beta1.RdbcCondition{Type: conditionType, Status: status, Message: message}
+	}
+	tests := []struct {
+		name       string
+		conditions []v1beta1.RdbcCondition
+		want       string
+	}{
+		{name: "no conditions", want: ""},
+		{
+			name:       "ready",
+			conditions: []v1beta1.RdbcCondition{condition(v1beta1.RdbcConditionReady, corev1.ConditionTrue, "db is active")},
+			want:       "db is active",
+		},
+		{
+			name: "degraded wins over ready",
+			conditions: []v1beta1.RdbcCondition{
+				condition(v1beta1.RdbcConditionReady, corev1.ConditionTrue, "db is active"),
+				condition(v1beta1.RdbcConditionDegraded, corev1.ConditionTrue, "size can't be changed"),
+			},
+			want: "size can't be changed",
+		},
+		{
+			name: "recovered from degraded",
+			conditions: []v1beta1.RdbcCondition{
+				condition(v1beta1.RdbcConditionDegraded, corev1.ConditionFalse, "size can't be changed"),
+				condition(v1beta1.RdbcConditionReady, corev1.ConditionFalse, "db is pending"),
+			},
+			want: "db is pending",
+		},
+		{
+			name:       "only other conditions",
+			conditions: []v1beta1.RdbcCondition{condition(v1beta1.RdbcConditionProvisioning, corev1.ConditionTrue, "creating db")},
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &v1beta1.Rdbc{Status: v1beta1.RdbcStatus{Conditions: tt.conditions}}
+			out := &Rdbc{}
+			if err := Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc(in, out); err != nil {
+				t.Fatalf("Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc() error = %v", err)
+			}
+			if out.Status.Message != tt.want {
+				t.Errorf("status.message = %q, want %q", out.Status.Message, tt.want)
+			}
+		})
+	}
+}
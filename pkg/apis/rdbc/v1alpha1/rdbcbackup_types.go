@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RdbcBackupSpec defines the export of the Rdbc db
+// +k8s:openapi-gen=true
+type RdbcBackupSpec struct {
+	// RdbcName is the Rdbc in the backup namespace to back up
+	RdbcName string `json:"rdbcName"`
+	// Destination is the storage the db is exported to
+	Destination BackupDestination `json:"destination"`
+}
+
+// BackupDestination is the storage of the db exports, exactly one of the storages must be set
+// +k8s:openapi-gen=true
+type BackupDestination struct {
+	// MountPoint is a path mounted on all the Redis Enterprise cluster nodes
+	MountPoint *MountPointDestination `json:"mountPoint,omitempty"`
+	// S3 is an S3 compatible storage, e.g. AWS S3 or MinIO
+	S3 *S3Destination `json:"s3,omitempty"`
+}
+
+// MountPointDestination is a path mounted on all the Redis Enterprise cluster nodes
+// +k8s:openapi-gen=true
+type MountPointDestination struct {
+	// Path is the absolute path of the mount point
+	Path string `json:"path"`
+}
+
+// S3Destination is an S3 compatible storage
+// +k8s:openapi-gen=true
+type S3Destination struct {
+	// Endpoint is the url of the S3 compatible storage, e.g. http://minio.minio:9000, empty for AWS S3
+	Endpoint string `json:"endpoint,omitempty"`
+	// Bucket is the bucket name
+	Bucket string `json:"bucket"`
+	// Subdir is the path in the bucket, each backup is exported to <subdir>/<namespace>/<backup name>
+	Subdir string `json:"subdir,omitempty"`
+	// Region is the region of the bucket the operator deletes the pruned exports from, defaults to us-east-1
+	Region string `json:"region,omitempty"`
+	// CredentialsSecretRef is the Secret in the backup namespace which contains the accessKeyId and secretAccessKey keys
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// BackupPhase is the lifecycle phase of the backup
+type BackupPhase string
+
+// Backup phases
+const (
+	BackupPhasePending   BackupPhase = "Pending"
+	BackupPhaseRunning   BackupPhase = "Running"
+	BackupPhaseCompleted BackupPhase = "Completed"
+	BackupPhaseFailed    BackupPhase = "Failed"
+)
+
+// RdbcBackupStatus defines the observed state of RdbcBackup
+// +k8s:openapi-gen=true
+type RdbcBackupStatus struct {
+	// Phase is the lifecycle phase of the backup
+	Phase BackupPhase `json:"phase,omitempty"`
+	// Message is the human readable message of the last reconcile
+	Message string `json:"message,omitempty"`
+	// DbUid is the uid of the exported db
+	DbUid int32 `json:"dbUid,omitempty"`
+	// DbName is the name of the exported db
+	DbName string `json:"dbName,omitempty"`
+	// Cluster is the RedisEnterpriseCluster of the db, empty for the cluster set by the operator env vars
+	Cluster string `json:"cluster,omitempty"`
+	// ActionUid is the uid of the Redis Enterprise export action
+	ActionUid string `json:"actionUid,omitempty"`
+	// Location is the storage the db is exported to, without the credentials
+	Location string `json:"location,omitempty"`
+	// StartTime is the time the export started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the export completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcBackup is the Schema for the rdbcbackups API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Rdbc",type="string",JSONPath=".spec.rdbcName"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Location",type="string",JSONPath=".status.location"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+type RdbcBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RdbcBackupSpec   `json:"spec,omitempty"`
+	Status RdbcBackupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcBackupList contains a list of RdbcBackup
+type RdbcBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RdbcBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RdbcBackup{}, &RdbcBackupList{})
+}
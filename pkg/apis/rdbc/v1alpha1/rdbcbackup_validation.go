@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"path"
+
+	"github.com/rdbc-operator/pkg/cron"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultBackupRetention is the number of the completed scheduled backups kept if the retention is not set
+const DefaultBackupRetention = 7
+
+// ValidateRdbcBackupSpec validates the backup spec
+func ValidateRdbcBackupSpec(spec *RdbcBackupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.RdbcName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rdbcName"), ""))
+	}
+	allErrs = append(allErrs, ValidateBackupDestination(&spec.Destination, fldPath.Child("destination"))...)
+	return allErrs
+}
+
+// ValidateRdbcBackupScheduleSpec validates the backup schedule spec
+func ValidateRdbcBackupScheduleSpec(spec *RdbcBackupScheduleSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.RdbcName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rdbcName"), ""))
+	}
+	if _, err := cron.Parse(spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), spec.Schedule, err.Error()))
+	}
+	if spec.Retention < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retention"), spec.Retention, "must not be negative"))
+	}
+	allErrs = append(allErrs, ValidateBackupDestination(&spec.Destination, fldPath.Child("destination"))...)
+	return allErrs
+}
+
+// ValidateBackupDestination validates that exactly one storage is set
+func ValidateBackupDestination(destination *BackupDestination, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case destination.MountPoint == nil && destination.S3 == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "mountPoint or s3 must be set"))
+	case destination.MountPoint != nil && destination.S3 != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("s3"), "may not be set together with mountPoint"))
+	case destination.MountPoint != nil:
+		if !path.IsAbs(destination.MountPoint.Path) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPoint", "path"), destination.MountPoint.Path, "must be an absolute path"))
+		}
+	case destination.S3 != nil:
+		if destination.S3.Bucket == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucket"), ""))
+		}
+		if destination.S3.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "credentialsSecretRef", "name"), ""))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RdbcBackupScheduleSpec defines the scheduled backups of the Rdbc db
+// +k8s:openapi-gen=true
+type RdbcBackupScheduleSpec struct {
+	// RdbcName is the Rdbc in the schedule namespace to back up
+	RdbcName string `json:"rdbcName"`
+	// Schedule is the cron schedule of the backups in UTC, e.g. "0 3 * * *" or "@daily"
+	Schedule string `json:"schedule"`
+	// Destination is the storage the db is exported to
+	Destination BackupDestination `json:"destination"`
+	// Retention is the number of the completed backups to keep, defaults to 7. The older RdbcBackups are deleted
+	// with their S3 exports, the mount point exports are not deleted and must be expired by the storage
+	Retention int `json:"retention,omitempty"`
+	// Suspend stops the scheduling of the new backups
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// RdbcBackupScheduleStatus defines the observed state of RdbcBackupSchedule
+// +k8s:openapi-gen=true
+type RdbcBackupScheduleStatus struct {
+	// Message is the human readable message of the last reconcile
+	Message string `json:"message,omitempty"`
+	// LastScheduleTime is the last time a backup was scheduled
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextScheduleTime is the time of the next backup
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// LastBackup is the name of the last scheduled RdbcBackup
+	LastBackup string `json:"lastBackup,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcBackupSchedule is the Schema for the rdbcbackupschedules API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Rdbc",type="string",JSONPath=".spec.rdbcName"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last",type="date",JSONPath=".status.lastScheduleTime"
+type RdbcBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RdbcBackupScheduleSpec   `json:"spec,omitempty"`
+	Status RdbcBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcBackupScheduleList contains a list of RdbcBackupSchedule
+type RdbcBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RdbcBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RdbcBackupSchedule{}, &RdbcBackupScheduleList{})
+}
@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RdbcQuotaSpec defines the limits of the Rdbcs in the quota namespace
+// +k8s:openapi-gen=true
+type RdbcQuotaSpec struct {
+	// Hard are the limits of the sum of all the Rdbcs in the namespace
+	Hard RdbcQuotaLimits `json:"hard"`
+}
+
+// RdbcQuotaLimits are the quota limits, the unset limits are not enforced
+// +k8s:openapi-gen=true
+type RdbcQuotaLimits struct {
+	// Memory is the provisioned memory in Megabytes, the size of a replicated db is counted twice
+	// +kubebuilder:validation:Minimum=0
+	Memory *int `json:"memory,omitempty"`
+	// Dbs is the number of the dbs
+	// +kubebuilder:validation:Minimum=0
+	Dbs *int `json:"dbs,omitempty"`
+	// Shards is the number of the shards, the replica shards are not counted
+	// +kubebuilder:validation:Minimum=0
+	Shards *int `json:"shards,omitempty"`
+	// ReplicatedDbs is the number of the dbs with replication, 0 disallows the replication
+	// +kubebuilder:validation:Minimum=0
+	ReplicatedDbs *int `json:"replicatedDbs,omitempty"`
+}
+
+// RdbcQuotaUsage is the sum of the Rdbcs in the namespace, counted the same way as the limits
+// +k8s:openapi-gen=true
+type RdbcQuotaUsage struct {
+	Memory        int `json:"memory"`
+	Dbs           int `json:"dbs"`
+	Shards        int `json:"shards"`
+	ReplicatedDbs int `json:"replicatedDbs"`
+}
+
+// RdbcQuotaStatus defines the observed state of RdbcQuota
+// +k8s:openapi-gen=true
+type RdbcQuotaStatus struct {
+	// Message is the human readable message of the last reconcile, lists the exceeded limits
+	Message string `json:"message,omitempty"`
+	// Hard are the enforced limits
+	Hard RdbcQuotaLimits `json:"hard,omitempty"`
+	// Used is the current usage of the namespace
+	Used RdbcQuotaUsage `json:"used,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcQuota is the Schema for the rdbcquotas API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Memory",type="integer",JSONPath=".status.used.memory",description="Used memory in Megabytes"
+// +kubebuilder:printcolumn:name="Memory Limit",type="integer",JSONPath=".status.hard.memory",description="Memory limit in Megabytes"
+// +kubebuilder:printcolumn:name="Dbs",type="integer",JSONPath=".status.used.dbs"
+// +kubebuilder:printcolumn:name="Dbs Limit",type="integer",JSONPath=".status.hard.dbs"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RdbcQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RdbcQuotaSpec   `json:"spec,omitempty"`
+	Status RdbcQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcQuotaList contains a list of RdbcQuota
+type RdbcQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RdbcQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RdbcQuota{}, &RdbcQuotaList{})
+}
@@ -0,0 +1,23 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateRdbcQuotaSpec validates the quota limits
+func ValidateRdbcQuotaSpec(spec *RdbcQuotaSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	hardPath := fldPath.Child("hard")
+	limits := map[string]*int{
+		"memory":        spec.Hard.Memory,
+		"dbs":           spec.Hard.Dbs,
+		"shards":        spec.Hard.Shards,
+		"replicatedDbs": spec.Hard.ReplicatedDbs,
+	}
+	for _, name := range []string{"memory", "dbs", "shards", "replicatedDbs"} {
+		if limit := limits[name]; limit != nil && *limit < 0 {
+			allErrs = append(allErrs, field.Invalid(hardPath.Child(name), *limit, "must not be negative"))
+		}
+	}
+	return allErrs
+}
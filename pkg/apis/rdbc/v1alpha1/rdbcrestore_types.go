@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RdbcRestoreSpec defines the import of the RDB files into the Rdbc db
+// +k8s:openapi-gen=true
+type RdbcRestoreSpec struct {
+	// RdbcName is the Rdbc in the restore namespace to import the files into, the Rdbc must be ready
+	RdbcName string `json:"rdbcName"`
+	// BackupRef is the completed RdbcBackup in the restore namespace to restore, the files are looked up in its location
+	BackupRef string `json:"backupRef,omitempty"`
+	// Source is the storage to restore from if backupRef is not set, the files are looked up in it as is
+	Source *BackupDestination `json:"source,omitempty"`
+	// Files are the names of the RDB files in the backup location or in the source,
+	// Redis Enterprise doesn't report the names of the exported files, thus they must be listed
+	Files []string `json:"files"`
+	// Flush deletes all the keys of the db before the import
+	Flush bool `json:"flush,omitempty"`
+}
+
+// RestorePhase is the lifecycle phase of the restore
+type RestorePhase string
+
+// Restore phases
+const (
+	RestorePhasePending   RestorePhase = "Pending"
+	RestorePhaseRunning   RestorePhase = "Running"
+	RestorePhaseCompleted RestorePhase = "Completed"
+	RestorePhaseFailed    RestorePhase = "Failed"
+)
+
+// RdbcRestore condition types
+const (
+	// RdbcRestoreConditionFlushed is true once the db was flushed before the import
+	RdbcRestoreConditionFlushed RdbcConditionType = "Flushed"
+	// RdbcRestoreConditionComplete is true once the import completed
+	RdbcRestoreConditionComplete RdbcConditionType = "Complete"
+	// RdbcRestoreConditionFailed is true if the restore failed
+	RdbcRestoreConditionFailed RdbcConditionType = "Failed"
+)
+
+// RdbcRestoreStatus defines the observed state of RdbcRestore
+// +k8s:openapi-gen=true
+type RdbcRestoreStatus struct {
+	// Phase is the lifecycle phase of the restore
+	Phase RestorePhase `json:"phase,omitempty"`
+	// Message is the human readable message of the last reconcile
+	Message string `json:"message,omitempty"`
+	// Conditions are the latest observations of the restore state
+	Conditions []RdbcCondition `json:"conditions,omitempty"`
+	// DbUid is the uid of the db the files are imported into
+	DbUid int32 `json:"dbUid,omitempty"`
+	// Cluster is the RedisEnterpriseCluster of the db, empty for the cluster set by the operator env vars
+	Cluster string `json:"cluster,omitempty"`
+	// ActionUid is the uid of the Redis Enterprise import action
+	ActionUid string `json:"actionUid,omitempty"`
+	// Location is the storage the files are imported from, without the credentials
+	Location string `json:"location,omitempty"`
+	// StartTime is the time the import started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the import completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcRestore is the Schema for the rdbcrestores API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Rdbc",type="string",JSONPath=".spec.rdbcName"
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupRef"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+type RdbcRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RdbcRestoreSpec   `json:"spec,omitempty"`
+	Status RdbcRestoreStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcRestoreList contains a list of RdbcRestore
+type RdbcRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RdbcRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RdbcRestore{}, &RdbcRestoreList{})
+}
@@ -0,0 +1,30 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateRdbcRestoreSpec validates the restore spec
+func ValidateRdbcRestoreSpec(spec *RdbcRestoreSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.RdbcName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rdbcName"), ""))
+	}
+	switch {
+	case spec.BackupRef == "" && spec.Source == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "backupRef or source must be set"))
+	case spec.BackupRef != "" && spec.Source != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("source"), "may not be set together with backupRef"))
+	case spec.Source != nil:
+		allErrs = append(allErrs, ValidateBackupDestination(spec.Source, fldPath.Child("source"))...)
+	}
+	if len(spec.Files) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("files"), ""))
+	}
+	for i, file := range spec.Files {
+		if file == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("files").Index(i), ""))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RedisEnterpriseClusterSpec defines the Redis Enterprise cluster API access
+// +k8s:openapi-gen=true
+type RedisEnterpriseClusterSpec struct {
+	// APIUrl is the Redis Enterprise REST API url
+	APIUrl string `json:"apiUrl"`
+	// CredentialsSecretRef is the Secret which contains the cluster admin username and password
+	CredentialsSecretRef corev1.SecretReference `json:"credentialsSecretRef"`
+	// TLS is the API TLS trust and client certificate
+	TLS *ClusterTLS `json:"tls,omitempty"`
+	// Default marks the cluster used by the Rdbcs without clusterRef, only a single cluster may be the default
+	Default bool `json:"default,omitempty"`
+	// ExportLocationSecretRef is the Secret which contains the Redis Enterprise export location JSON
+	// in the location key, the dbs are exported there by the Snapshot deletion policy
+	ExportLocationSecretRef *corev1.SecretReference `json:"exportLocationSecretRef,omitempty"`
+}
+
+// ClusterTLS defines the TLS trust and client certificate of the Redis Enterprise API
+// +k8s:openapi-gen=true
+type ClusterTLS struct {
+	// CASecretRef is the Secret which contains the API CA bundle under ca.crt key
+	CASecretRef *corev1.SecretReference `json:"caSecretRef,omitempty"`
+	// CAConfigMapRef is the ConfigMap which contains the API CA bundle under ca.crt key, used if CASecretRef is not set
+	CAConfigMapRef *ConfigMapReference `json:"caConfigMapRef,omitempty"`
+	// ClientCertSecretRef is the kubernetes.io/tls Secret presented to the API for mTLS
+	ClientCertSecretRef *corev1.SecretReference `json:"clientCertSecretRef,omitempty"`
+	// InsecureSkipVerify disables the API certificate verification, not secure
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ConfigMapReference references a ConfigMap in any namespace
+// +k8s:openapi-gen=true
+type ConfigMapReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// RedisEnterpriseClusterStatus defines the observed state of RedisEnterpriseCluster
+// +k8s:openapi-gen=true
+type RedisEnterpriseClusterStatus struct {
+	// Reachable is true if the API responded to the last probe
+	Reachable bool `json:"reachable"`
+	// Message is the human readable message of the last probe
+	Message string `json:"message,omitempty"`
+	// Version is the Redis Enterprise software version
+	Version string `json:"version,omitempty"`
+	// Capacity is the cluster memory capacity
+	Capacity *ClusterCapacity `json:"capacity,omitempty"`
+	// LastProbeTime is the last time the API was probed
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// ClusterCapacity is the cluster memory capacity in Megabytes
+// +k8s:openapi-gen=true
+type ClusterCapacity struct {
+	// Nodes is the number of the cluster nodes
+	Nodes int `json:"nodes"`
+	// TotalMemory is the total memory of the cluster nodes
+	TotalMemory int64 `json:"totalMemory"`
+	// ProvisionedMemory is the memory provisioned to the dbs, including the replicas
+	ProvisionedMemory int64 `json:"provisionedMemory"`
+	// FreeMemory is the memory which is not provisioned to any db
+	FreeMemory int64 `json:"freeMemory"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisEnterpriseCluster is the Schema for the redisenterpriseclusters API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.apiUrl"
+// +kubebuilder:printcolumn:name="Default",type="boolean",JSONPath=".spec.default"
+// +kubebuilder:printcolumn:name="Reachable",type="boolean",JSONPath=".status.reachable"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Free",type="integer",JSONPath=".status.capacity.freeMemory",description="Free memory in Megabytes"
+type RedisEnterpriseCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisEnterpriseClusterSpec   `json:"spec,omitempty"`
+	Status RedisEnterpriseClusterStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedisEnterpriseClusterList contains a list of RedisEnterpriseCluster
+type RedisEnterpriseClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedisEnterpriseCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedisEnterpriseCluster{}, &RedisEnterpriseClusterList{})
+}
@@ -5,31 +5,679 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptFrom) DeepCopyInto(out *AdoptFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptFrom.
+func (in *AdoptFrom) DeepCopy() *AdoptFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
+	*out = *in
+	if in.MountPoint != nil {
+		in, out := &in.MountPoint, &out.MountPoint
+		*out = new(MountPointDestination)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Destination)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
+func (in *BackupDestination) DeepCopy() *BackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCapacity) DeepCopyInto(out *ClusterCapacity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCapacity.
+func (in *ClusterCapacity) DeepCopy() *ClusterCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTLS) DeepCopyInto(out *ClusterTLS) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.CAConfigMapRef != nil {
+		in, out := &in.CAConfigMapRef, &out.CAConfigMapRef
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTLS.
+func (in *ClusterTLS) DeepCopy() *ClusterTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MountPointDestination) DeepCopyInto(out *MountPointDestination) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MountPointDestination.
+func (in *MountPointDestination) DeepCopy() *MountPointDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(MountPointDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
+func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotation) DeepCopyInto(out *PasswordRotation) {
+	*out = *in
+	out.Interval = in.Interval
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotation.
+func (in *PasswordRotation) DeepCopy() *PasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rdbc) DeepCopyInto(out *Rdbc) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rdbc.
+func (in *Rdbc) DeepCopy() *Rdbc {
+	if in == nil {
+		return nil
+	}
+	out := new(Rdbc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Rdbc) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcAdoptionStatus) DeepCopyInto(out *RdbcAdoptionStatus) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rejected != nil {
+		in, out := &in.Rejected, &out.Rejected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcAdoptionStatus.
+func (in *RdbcAdoptionStatus) DeepCopy() *RdbcAdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcAdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackup) DeepCopyInto(out *RdbcBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackup.
+func (in *RdbcBackup) DeepCopy() *RdbcBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupList) DeepCopyInto(out *RdbcBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RdbcBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupList.
+func (in *RdbcBackupList) DeepCopy() *RdbcBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupSchedule) DeepCopyInto(out *RdbcBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupSchedule.
+func (in *RdbcBackupSchedule) DeepCopy() *RdbcBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupScheduleList) DeepCopyInto(out *RdbcBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RdbcBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupScheduleList.
+func (in *RdbcBackupScheduleList) DeepCopy() *RdbcBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupScheduleSpec) DeepCopyInto(out *RdbcBackupScheduleSpec) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupScheduleSpec.
+func (in *RdbcBackupScheduleSpec) DeepCopy() *RdbcBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupScheduleStatus) DeepCopyInto(out *RdbcBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupScheduleStatus.
+func (in *RdbcBackupScheduleStatus) DeepCopy() *RdbcBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupSpec) DeepCopyInto(out *RdbcBackupSpec) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupSpec.
+func (in *RdbcBackupSpec) DeepCopy() *RdbcBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcBackupStatus) DeepCopyInto(out *RdbcBackupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcBackupStatus.
+func (in *RdbcBackupStatus) DeepCopy() *RdbcBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcCondition) DeepCopyInto(out *RdbcCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcCondition.
+func (in *RdbcCondition) DeepCopy() *RdbcCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcList) DeepCopyInto(out *RdbcList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Rdbc, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcList.
+func (in *RdbcList) DeepCopy() *RdbcList {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcModule) DeepCopyInto(out *RdbcModule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcModule.
+func (in *RdbcModule) DeepCopy() *RdbcModule {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcPersistenceStatus) DeepCopyInto(out *RdbcPersistenceStatus) {
+	*out = *in
+	if in.SnapshotPolicy != nil {
+		in, out := &in.SnapshotPolicy, &out.SnapshotPolicy
+		*out = make([]SnapshotPolicy, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcPersistenceStatus.
+func (in *RdbcPersistenceStatus) DeepCopy() *RdbcPersistenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcPersistenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcQuota) DeepCopyInto(out *RdbcQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcQuota.
+func (in *RdbcQuota) DeepCopy() *RdbcQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcQuotaLimits) DeepCopyInto(out *RdbcQuotaLimits) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(int)
+		**out = **in
+	}
+	if in.Dbs != nil {
+		in, out := &in.Dbs, &out.Dbs
+		*out = new(int)
+		**out = **in
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReplicatedDbs != nil {
+		in, out := &in.ReplicatedDbs, &out.ReplicatedDbs
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcQuotaLimits.
+func (in *RdbcQuotaLimits) DeepCopy() *RdbcQuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcQuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcQuotaList) DeepCopyInto(out *RdbcQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RdbcQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcQuotaList.
+func (in *RdbcQuotaList) DeepCopy() *RdbcQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RdbcQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcQuotaSpec) DeepCopyInto(out *RdbcQuotaSpec) {
+	*out = *in
+	in.Hard.DeepCopyInto(&out.Hard)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcQuotaSpec.
+func (in *RdbcQuotaSpec) DeepCopy() *RdbcQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcQuotaStatus) DeepCopyInto(out *RdbcQuotaStatus) {
+	*out = *in
+	in.Hard.DeepCopyInto(&out.Hard)
+	out.Used = in.Used
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcQuotaStatus.
+func (in *RdbcQuotaStatus) DeepCopy() *RdbcQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcQuotaUsage) DeepCopyInto(out *RdbcQuotaUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcQuotaUsage.
+func (in *RdbcQuotaUsage) DeepCopy() *RdbcQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcRestore) DeepCopyInto(out *RdbcRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rdbc.
-func (in *Rdbc) DeepCopy() *Rdbc {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcRestore.
+func (in *RdbcRestore) DeepCopy() *RdbcRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(Rdbc)
+	out := new(RdbcRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Rdbc) DeepCopyObject() runtime.Object {
+func (in *RdbcRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -37,13 +685,13 @@ func (in *Rdbc) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RdbcList) DeepCopyInto(out *RdbcList) {
+func (in *RdbcRestoreList) DeepCopyInto(out *RdbcRestoreList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Rdbc, len(*in))
+		*out = make([]RdbcRestore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -51,27 +699,143 @@ func (in *RdbcList) DeepCopyInto(out *RdbcList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcList.
-func (in *RdbcList) DeepCopy() *RdbcList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcRestoreList.
+func (in *RdbcRestoreList) DeepCopy() *RdbcRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(RdbcList)
+	out := new(RdbcRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RdbcList) DeepCopyObject() runtime.Object {
+func (in *RdbcRestoreList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcRestoreSpec) DeepCopyInto(out *RdbcRestoreSpec) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(BackupDestination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcRestoreSpec.
+func (in *RdbcRestoreSpec) DeepCopy() *RdbcRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcRestoreStatus) DeepCopyInto(out *RdbcRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RdbcCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcRestoreStatus.
+func (in *RdbcRestoreStatus) DeepCopy() *RdbcRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcSnapshotStatus) DeepCopyInto(out *RdbcSnapshotStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcSnapshotStatus.
+func (in *RdbcSnapshotStatus) DeepCopy() *RdbcSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RdbcSpec) DeepCopyInto(out *RdbcSpec) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordPolicy != nil {
+		in, out := &in.PasswordPolicy, &out.PasswordPolicy
+		*out = new(PasswordPolicy)
+		**out = **in
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotation)
+		**out = **in
+	}
+	if in.ShardKeyRegex != nil {
+		in, out := &in.ShardKeyRegex, &out.ShardKeyRegex
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SnapshotPolicy != nil {
+		in, out := &in.SnapshotPolicy, &out.SnapshotPolicy
+		*out = make([]SnapshotPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]RdbcModule, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdoptFrom != nil {
+		in, out := &in.AdoptFrom, &out.AdoptFrom
+		*out = new(AdoptFrom)
+		**out = **in
+	}
 	return
 }
 
@@ -88,6 +852,41 @@ func (in *RdbcSpec) DeepCopy() *RdbcSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RdbcStatus) DeepCopyInto(out *RdbcStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RdbcCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(RdbcPersistenceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastPasswordRotation != nil {
+		in, out := &in.LastPasswordRotation, &out.LastPasswordRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(RdbcAdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionSnapshot != nil {
+		in, out := &in.DeletionSnapshot, &out.DeletionSnapshot
+		*out = new(RdbcSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(RdbcUsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -100,3 +899,170 @@ func (in *RdbcStatus) DeepCopy() *RdbcStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RdbcUsageStatus) DeepCopyInto(out *RdbcUsageStatus) {
+	*out = *in
+	out.EvictionsPerSec = in.EvictionsPerSec.DeepCopy()
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RdbcUsageStatus.
+func (in *RdbcUsageStatus) DeepCopy() *RdbcUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RdbcUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisEnterpriseCluster) DeepCopyInto(out *RedisEnterpriseCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisEnterpriseCluster.
+func (in *RedisEnterpriseCluster) DeepCopy() *RedisEnterpriseCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisEnterpriseCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedisEnterpriseCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisEnterpriseClusterList) DeepCopyInto(out *RedisEnterpriseClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RedisEnterpriseCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisEnterpriseClusterList.
+func (in *RedisEnterpriseClusterList) DeepCopy() *RedisEnterpriseClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisEnterpriseClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedisEnterpriseClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisEnterpriseClusterSpec) DeepCopyInto(out *RedisEnterpriseClusterSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClusterTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportLocationSecretRef != nil {
+		in, out := &in.ExportLocationSecretRef, &out.ExportLocationSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisEnterpriseClusterSpec.
+func (in *RedisEnterpriseClusterSpec) DeepCopy() *RedisEnterpriseClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisEnterpriseClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisEnterpriseClusterStatus) DeepCopyInto(out *RedisEnterpriseClusterStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(ClusterCapacity)
+		**out = **in
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisEnterpriseClusterStatus.
+func (in *RedisEnterpriseClusterStatus) DeepCopy() *RedisEnterpriseClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisEnterpriseClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Destination) DeepCopyInto(out *S3Destination) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Destination.
+func (in *S3Destination) DeepCopy() *S3Destination {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Destination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPolicy) DeepCopyInto(out *SnapshotPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPolicy.
+func (in *SnapshotPolicy) DeepCopy() *SnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -11,9 +11,278 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.Rdbc":       schema_pkg_apis_rdbc_v1alpha1_Rdbc(ref),
-		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcSpec":   schema_pkg_apis_rdbc_v1alpha1_RdbcSpec(ref),
-		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcStatus": schema_pkg_apis_rdbc_v1alpha1_RdbcStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.AdoptFrom":                    schema_pkg_apis_rdbc_v1alpha1_AdoptFrom(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination":            schema_pkg_apis_rdbc_v1alpha1_BackupDestination(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ClusterCapacity":              schema_pkg_apis_rdbc_v1alpha1_ClusterCapacity(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ClusterTLS":                   schema_pkg_apis_rdbc_v1alpha1_ClusterTLS(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ConfigMapReference":           schema_pkg_apis_rdbc_v1alpha1_ConfigMapReference(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.MountPointDestination":        schema_pkg_apis_rdbc_v1alpha1_MountPointDestination(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.PasswordPolicy":               schema_pkg_apis_rdbc_v1alpha1_PasswordPolicy(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.PasswordRotation":             schema_pkg_apis_rdbc_v1alpha1_PasswordRotation(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.Rdbc":                         schema_pkg_apis_rdbc_v1alpha1_Rdbc(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcAdoptionStatus":           schema_pkg_apis_rdbc_v1alpha1_RdbcAdoptionStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackup":                   schema_pkg_apis_rdbc_v1alpha1_RdbcBackup(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupSchedule":           schema_pkg_apis_rdbc_v1alpha1_RdbcBackupSchedule(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupScheduleSpec":       schema_pkg_apis_rdbc_v1alpha1_RdbcBackupScheduleSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupScheduleStatus":     schema_pkg_apis_rdbc_v1alpha1_RdbcBackupScheduleStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupSpec":               schema_pkg_apis_rdbc_v1alpha1_RdbcBackupSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupStatus":             schema_pkg_apis_rdbc_v1alpha1_RdbcBackupStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcCondition":                schema_pkg_apis_rdbc_v1alpha1_RdbcCondition(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcModule":                   schema_pkg_apis_rdbc_v1alpha1_RdbcModule(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcPersistenceStatus":        schema_pkg_apis_rdbc_v1alpha1_RdbcPersistenceStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuota":                    schema_pkg_apis_rdbc_v1alpha1_RdbcQuota(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaLimits":              schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaLimits(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaSpec":                schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaStatus":              schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaUsage":               schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaUsage(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestore":                  schema_pkg_apis_rdbc_v1alpha1_RdbcRestore(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestoreSpec":              schema_pkg_apis_rdbc_v1alpha1_RdbcRestoreSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestoreStatus":            schema_pkg_apis_rdbc_v1alpha1_RdbcRestoreStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcSnapshotStatus":           schema_pkg_apis_rdbc_v1alpha1_RdbcSnapshotStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcSpec":                     schema_pkg_apis_rdbc_v1alpha1_RdbcSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcStatus":                   schema_pkg_apis_rdbc_v1alpha1_RdbcStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcUsageStatus":              schema_pkg_apis_rdbc_v1alpha1_RdbcUsageStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseCluster":       schema_pkg_apis_rdbc_v1alpha1_RedisEnterpriseCluster(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseClusterSpec":   schema_pkg_apis_rdbc_v1alpha1_RedisEnterpriseClusterSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseClusterStatus": schema_pkg_apis_rdbc_v1alpha1_RedisEnterpriseClusterStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.S3Destination":                schema_pkg_apis_rdbc_v1alpha1_S3Destination(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.SnapshotPolicy":               schema_pkg_apis_rdbc_v1alpha1_SnapshotPolicy(ref),
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_AdoptFrom(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AdoptFrom identifies the existing db to adopt, by uid or by name",
+				Properties: map[string]spec.Schema{
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Uid of the existing db",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the existing db",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun reports the changes the spec would make to the db in status.adoption, the db is not adopted until DryRun is unset",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_BackupDestination(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupDestination is the storage of the db exports, exactly one of the storages must be set",
+				Properties: map[string]spec.Schema{
+					"mountPoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountPoint is a path mounted on all the Redis Enterprise cluster nodes",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.MountPointDestination"),
+						},
+					},
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Description: "S3 is an S3 compatible storage, e.g. AWS S3 or MinIO",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.S3Destination"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.MountPointDestination", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.S3Destination"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_ClusterCapacity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterCapacity is the cluster memory capacity in Megabytes",
+				Properties: map[string]spec.Schema{
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes is the number of the cluster nodes",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"totalMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalMemory is the total memory of the cluster nodes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"provisionedMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProvisionedMemory is the memory provisioned to the dbs, including the replicas",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"freeMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FreeMemory is the memory which is not provisioned to any db",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"nodes", "totalMemory", "provisionedMemory", "freeMemory"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_ClusterTLS(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterTLS defines the TLS trust and client certificate of the Redis Enterprise API",
+				Properties: map[string]spec.Schema{
+					"caSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CASecretRef is the Secret which contains the API CA bundle under ca.crt key",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+						},
+					},
+					"caConfigMapRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CAConfigMapRef is the ConfigMap which contains the API CA bundle under ca.crt key, used if CASecretRef is not set",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ConfigMapReference"),
+						},
+					},
+					"clientCertSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientCertSecretRef is the kubernetes.io/tls Secret presented to the API for mTLS",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+						},
+					},
+					"insecureSkipVerify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InsecureSkipVerify disables the API certificate verification, not secure",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ConfigMapReference", "k8s.io/api/core/v1.SecretReference"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_ConfigMapReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConfigMapReference references a ConfigMap in any namespace",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name", "namespace"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_MountPointDestination(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MountPointDestination is a path mounted on all the Redis Enterprise cluster nodes",
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the absolute path of the mount point",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_PasswordPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PasswordPolicy defines the generated passwords",
+				Properties: map[string]spec.Schema{
+					"length": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Length of the generated passwords, 16 to 128",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"charset": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Charset of the generated passwords, alphanumeric or alphanumeric-symbols",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_PasswordRotation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PasswordRotation defines the automatic db password rotation, the rotation may be requested manually by the rdbc.cnative/rotate-password annotation as well",
+				Properties: map[string]spec.Schema{
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interval is the time between the rotations, e.g. 2160h for 90 days, at least 1h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"interval"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -60,52 +329,1411 @@ func schema_pkg_apis_rdbc_v1alpha1_Rdbc(ref common.ReferenceCallback) common.Ope
 	}
 }
 
-func schema_pkg_apis_rdbc_v1alpha1_RdbcSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rdbc_v1alpha1_RdbcAdoptionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RdbcSpec defines the desired state of Rdbc",
+				Description: "RdbcAdoptionStatus is the report of the existing db adoption",
 				Properties: map[string]spec.Schema{
-					"name": {
+					"dbUid": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "DbUid is the uid of the adopted db",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"size": {
+					"dbName": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "DbName is the name of the adopted db",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"password": {
+					"adopted": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Adopted is true once the operator manages the db, false for the dry-run",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"changes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Changes are the differences between the spec and the db, applied to the db once adopted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"rejected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rejected are the spec changes which can't be applied in place, the db keeps its settings",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"name", "size", "password"},
+				Required: []string{"dbUid", "dbName", "adopted"},
 			},
 		},
 		Dependencies: []string{},
 	}
 }
 
-func schema_pkg_apis_rdbc_v1alpha1_RdbcStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rdbc_v1alpha1_RdbcBackup(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RdbcStatus defines the observed state of Rdbc",
+				Description: "RdbcBackup is the Schema for the rdbcbackups API",
 				Properties: map[string]spec.Schema{
-					"message": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupSpec", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcBackupSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcBackupSchedule is the Schema for the rdbcbackupschedules API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupScheduleSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupScheduleStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupScheduleSpec", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcBackupScheduleStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcBackupScheduleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcBackupScheduleSpec defines the scheduled backups of the Rdbc db",
+				Properties: map[string]spec.Schema{
+					"rdbcName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RdbcName is the Rdbc in the schedule namespace to back up",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is the cron schedule of the backups in UTC, e.g. \"0 3 * * *\" or \"@daily\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination is the storage the db is exported to",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination"),
+						},
+					},
+					"retention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retention is the number of the completed backups to keep, defaults to 7. The older RdbcBackups are deleted with their S3 exports, the mount point exports are not deleted and must be expired by the storage",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"suspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Suspend stops the scheduling of the new backups",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"rdbcName", "schedule", "destination"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcBackupScheduleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcBackupScheduleStatus defines the observed state of RdbcBackupSchedule",
+				Properties: map[string]spec.Schema{
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message of the last reconcile",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduleTime is the last time a backup was scheduled",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextScheduleTime is the time of the next backup",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastBackup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastBackup is the name of the last scheduled RdbcBackup",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcBackupSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcBackupSpec defines the export of the Rdbc db",
+				Properties: map[string]spec.Schema{
+					"rdbcName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RdbcName is the Rdbc in the backup namespace to back up",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination is the storage the db is exported to",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination"),
+						},
+					},
+				},
+				Required: []string{"rdbcName", "destination"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcBackupStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcBackupStatus defines the observed state of RdbcBackup",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the lifecycle phase of the backup",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message of the last reconcile",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dbUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbUid is the uid of the exported db",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dbName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbName is the name of the exported db",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the RedisEnterpriseCluster of the db, empty for the cluster set by the operator env vars",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"actionUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActionUid is the uid of the Redis Enterprise export action",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"location": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Location is the storage the db is exported to, without the credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the export started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the export completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcCondition describes the state of the Rdbc at a certain point",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcModule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcModule is the Redis module loaded by the db",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the module name, e.g. search, ReJSON, timeseries or bf",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the module semantic version, defaults to the latest version installed on the cluster",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Args are the module arguments",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcPersistenceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcPersistenceStatus is the effective persistence and eviction settings of the db",
+				Properties: map[string]spec.Schema{
+					"dataPersistence": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"aofPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"snapshotPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.SnapshotPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"evictionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.SnapshotPolicy"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcQuota(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcQuota is the Schema for the rdbcquotas API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaSpec", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaLimits(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcQuotaLimits are the quota limits, the unset limits are not enforced",
+				Properties: map[string]spec.Schema{
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory is the provisioned memory in Megabytes, the size of a replicated db is counted twice",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dbs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Dbs is the number of the dbs",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"shards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Shards is the number of the shards, the replica shards are not counted",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"replicatedDbs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicatedDbs is the number of the dbs with replication, 0 disallows the replication",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcQuotaSpec defines the limits of the Rdbcs in the quota namespace",
+				Properties: map[string]spec.Schema{
+					"hard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hard are the limits of the sum of all the Rdbcs in the namespace",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaLimits"),
+						},
+					},
+				},
+				Required: []string{"hard"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaLimits"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcQuotaStatus defines the observed state of RdbcQuota",
+				Properties: map[string]spec.Schema{
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message of the last reconcile, lists the exceeded limits",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hard are the enforced limits",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaLimits"),
+						},
+					},
+					"used": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Used is the current usage of the namespace",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaUsage"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaLimits", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcQuotaUsage"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcQuotaUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcQuotaUsage is the sum of the Rdbcs in the namespace, counted the same way as the limits",
+				Properties: map[string]spec.Schema{
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"dbs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"shards": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"replicatedDbs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+				Required: []string{"memory", "dbs", "shards", "replicatedDbs"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcRestore(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcRestore is the Schema for the rdbcrestores API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestoreSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestoreStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestoreSpec", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcRestoreStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcRestoreSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcRestoreSpec defines the import of the RDB files into the Rdbc db",
+				Properties: map[string]spec.Schema{
+					"rdbcName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RdbcName is the Rdbc in the restore namespace to import the files into, the Rdbc must be ready",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"backupRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackupRef is the completed RdbcBackup in the restore namespace to restore, the files are looked up in its location",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source is the storage to restore from if backupRef is not set, the files are looked up in it as is",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination"),
+						},
+					},
+					"files": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Files are the names of the RDB files in the backup location or in the source, Redis Enterprise doesn't report the names of the exported files, thus they must be listed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"flush": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flush deletes all the keys of the db before the import",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"rdbcName", "files"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.BackupDestination"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcRestoreStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcRestoreStatus defines the observed state of RdbcRestore",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the lifecycle phase of the restore",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message of the last reconcile",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest observations of the restore state",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcCondition"),
+									},
+								},
+							},
+						},
+					},
+					"dbUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbUid is the uid of the db the files are imported into",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the RedisEnterpriseCluster of the db, empty for the cluster set by the operator env vars",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"actionUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActionUid is the uid of the Redis Enterprise import action",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"location": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Location is the storage the files are imported from, without the credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the import started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the import completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcSnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcSnapshotStatus is the db export",
+				Properties: map[string]spec.Schema{
+					"actionUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActionUid is the uid of the Redis Enterprise export action",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"location": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Location is the storage the db is exported to, without the credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the export started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the export completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"actionUid", "location"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcSpec defines the desired state of Rdbc",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the Redis Enterprise db name, may be set on creation only",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the db memory limit in Megabytes, defaults to 100",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"password": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Password is the plaintext db password. Deprecated: use PasswordSecretRef, the operator moves the password to a Secret and clears this field",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"passwordSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordSecretRef selects the db password from a Secret in the Rdbc namespace, a password is generated if neither PasswordSecretRef nor Password is set",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"passwordPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordPolicy sets the length and the charset of the generated passwords, the unset fields default to the operator wide policy",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.PasswordPolicy"),
+						},
+					},
+					"passwordRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordRotation enables the automatic rotation of the db password",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.PasswordRotation"),
+						},
+					},
+					"replication": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replication enables in-memory replication of the db shards, defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"shardsCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ShardsCount is the number of the db shards, defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"shardKeyRegex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ShardKeyRegex are the regexes used to extract the hash tag from the keys of a sharded db, each regex must have a named capturing group called tag, defaults to the Redis Enterprise standard hashing policy",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"ossCluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSSCluster enables the OSS cluster API, defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"proxyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyPolicy is one of single, all-master-shards or all-nodes, defaults to all-master-shards for OSS cluster db and to single for any other db",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dataPersistence": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DataPersistence is one of disabled, aof or snapshot, defaults to disabled",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"aofPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AofPolicy is one of appendfsync-every-sec or appendfsync-always, applies to aof persistence only, defaults to appendfsync-every-sec",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"snapshotPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SnapshotPolicy are the snapshot rules, applies to snapshot persistence only, defaults to a snapshot every 12 hours if there was at least a single write",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.SnapshotPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"evictionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictionPolicy is the Redis eviction policy, defaults to volatile-lru",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"modules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Modules are the Redis modules loaded by the db, may be set on creation only",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcModule"),
+									},
+								},
+							},
+						},
+					},
+					"clusterRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterRef is the name of the RedisEnterpriseCluster to create the db on, defaults to the default cluster, may be set on creation only",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionPolicy is what happens to the db when the Rdbc is deleted: Delete, Retain or Snapshot, defaults to the operator wide policy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"driftPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftPolicy is what happens when the db settings are changed out of band, e.g. in the Redis Enterprise UI: Correct applies the spec back to the db, Report lists the drifted fields in the Drifted condition, defaults to Correct",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adoptFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdoptFrom imports an existing db instead of creating a new one, the spec is applied to the db once adopted",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.AdoptFrom"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.AdoptFrom", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.PasswordPolicy", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.PasswordRotation", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcModule", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.SnapshotPolicy", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcStatus defines the observed state of Rdbc",
+				Properties: map[string]spec.Schema{
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message of the last reconcile",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the lifecycle phase of the Rdbc",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest observations of the Rdbc state",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcCondition"),
+									},
+								},
+							},
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent Rdbc generation applied to the db",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dbUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbUid is the Redis Enterprise db uid",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint is the db endpoint as host:port",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the last time a change of the db state was synced into the status",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"memorySize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemorySize is the actual db size in Megabytes",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"pendingAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PendingAction is the uid of the Redis Enterprise action the db is waiting for",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"persistence": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Persistence is the effective persistence and eviction settings of the db",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcPersistenceStatus"),
+						},
+					},
+					"lastPasswordRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastPasswordRotation is the last time the db password was rotated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the RedisEnterpriseCluster the db was created on, empty for the cluster set by the operator env vars",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adoption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Adoption is the report of the existing db adoption",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcAdoptionStatus"),
+						},
+					},
+					"deletionSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionSnapshot is the db export made by the Snapshot deletion policy",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcSnapshotStatus"),
+						},
+					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage is the db usage of the last Redis Enterprise stats interval",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcUsageStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcAdoptionStatus", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcCondition", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcPersistenceStatus", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcSnapshotStatus", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RdbcUsageStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RdbcUsageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcUsageStatus is the db usage reported by the Redis Enterprise stats",
+				Properties: map[string]spec.Schema{
+					"usedMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UsedMemory is the memory used by the db in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"opsPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OpsPerSec is the rate of the db operations",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"connectedClients": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConnectedClients is the number of the client connections",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"evictionsPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictionsPerSec is the rate of the evicted keys, a decimal since the rates below 1 are common, e.g. 250m",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the time the usage was read from the cluster",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"usedMemory", "opsPerSec", "connectedClients", "evictionsPerSec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RedisEnterpriseCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RedisEnterpriseCluster is the Schema for the redisenterpriseclusters API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseClusterSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseClusterStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseClusterSpec", "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.RedisEnterpriseClusterStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RedisEnterpriseClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RedisEnterpriseClusterSpec defines the Redis Enterprise cluster API access",
+				Properties: map[string]spec.Schema{
+					"apiUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIUrl is the Redis Enterprise REST API url",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecretRef is the Secret which contains the cluster admin username and password",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS is the API TLS trust and client certificate",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ClusterTLS"),
+						},
+					},
+					"default": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Default marks the cluster used by the Rdbcs without clusterRef, only a single cluster may be the default",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"exportLocationSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExportLocationSecretRef is the Secret which contains the Redis Enterprise export location JSON in the location key, the dbs are exported there by the Snapshot deletion policy",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+						},
+					},
+				},
+				Required: []string{"apiUrl", "credentialsSecretRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ClusterTLS", "k8s.io/api/core/v1.SecretReference"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_RedisEnterpriseClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RedisEnterpriseClusterStatus defines the observed state of RedisEnterpriseCluster",
+				Properties: map[string]spec.Schema{
+					"reachable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reachable is true if the API responded to the last probe",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message of the last probe",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the Redis Enterprise software version",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Capacity is the cluster memory capacity",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ClusterCapacity"),
+						},
+					},
+					"lastProbeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastProbeTime is the last time the API was probed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"reachable"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1.ClusterCapacity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_S3Destination(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "S3Destination is an S3 compatible storage",
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint is the url of the S3 compatible storage, e.g. http://minio.minio:9000, empty for AWS S3",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bucket is the bucket name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subdir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subdir is the path in the bucket, each backup is exported to <subdir>/<namespace>/<backup name>",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Region is the region of the bucket the operator deletes the pruned exports from, defaults to us-east-1",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecretRef is the Secret in the backup namespace which contains the accessKeyId and secretAccessKey keys",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+				Required: []string{"bucket", "credentialsSecretRef"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1alpha1_SnapshotPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotPolicy takes a snapshot every Secs seconds if there were at least Writes writes",
+				Properties: map[string]spec.Schema{
+					"secs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"writes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
 				},
-				Required: []string{"message"},
+				Required: []string{"secs", "writes"},
 			},
 		},
 		Dependencies: []string{},
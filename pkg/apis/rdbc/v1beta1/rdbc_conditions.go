@@ -0,0 +1,55 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetCondition sets the condition of the given type,
+// the transition time is changed only when the condition status changes
+func (s *RdbcStatus) SetCondition(conditionType RdbcConditionType, status corev1.ConditionStatus, reason string, message string) {
+	setCondition(&s.Conditions, conditionType, status, reason, message)
+}
+
+// GetCondition returns the condition of the given type or nil if it's not set
+func (s *RdbcStatus) GetCondition(conditionType RdbcConditionType) *RdbcCondition {
+	return getCondition(s.Conditions, conditionType)
+}
+
+// IsConditionTrue returns true if the condition of the given type is set and true
+func (s *RdbcStatus) IsConditionTrue(conditionType RdbcConditionType) bool {
+	c := s.GetCondition(conditionType)
+	return c != nil && c.Status == corev1.ConditionTrue
+}
+
+func setCondition(conditions *[]RdbcCondition, conditionType RdbcConditionType, status corev1.ConditionStatus, reason string, message string) {
+	for i := range *conditions {
+		c := &(*conditions)[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status != status {
+			c.LastTransitionTime = metav1.Now()
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+	*conditions = append(*conditions, RdbcCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func getCondition(conditions []RdbcCondition, conditionType RdbcConditionType) *RdbcCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package v1beta1
+
+// Proxy policies
+const (
+	ProxyPolicySingle          = "single"
+	ProxyPolicyAllMasterShards = "all-master-shards"
+	ProxyPolicyAllNodes        = "all-nodes"
+)
+
+// Data persistence modes
+const (
+	DataPersistenceDisabled = "disabled"
+	DataPersistenceAof      = "aof"
+	DataPersistenceSnapshot = "snapshot"
+)
+
+// AOF policies
+const (
+	AofPolicyEverySec = "appendfsync-every-sec"
+	AofPolicyAlways   = "appendfsync-always"
+)
+
+// Eviction policies
+const (
+	EvictionPolicyVolatileLru    = "volatile-lru"
+	EvictionPolicyVolatileLfu    = "volatile-lfu"
+	EvictionPolicyVolatileTtl    = "volatile-ttl"
+	EvictionPolicyVolatileRandom = "volatile-random"
+	EvictionPolicyAllKeysLru     = "allkeys-lru"
+	EvictionPolicyAllKeysLfu     = "allkeys-lfu"
+	EvictionPolicyAllKeysRandom  = "allkeys-random"
+	EvictionPolicyNoEviction     = "noeviction"
+)
+
+// Deletion policies
+const (
+	// DeletionPolicyDelete deletes the db with the Rdbc
+	DeletionPolicyDelete = "Delete"
+	// DeletionPolicyRetain keeps the db when the Rdbc is deleted
+	DeletionPolicyRetain = "Retain"
+	// DeletionPolicySnapshot exports the db data and then deletes the db with the Rdbc
+	DeletionPolicySnapshot = "Snapshot"
+)
+
+// Drift policies
+const (
+	// DriftPolicyCorrect applies the spec back to the db changed out of band
+	DriftPolicyCorrect = "Correct"
+	// DriftPolicyReport reports the db settings changed out of band, the db is left as is
+	DriftPolicyReport = "Report"
+)
+
+// Charsets of the generated passwords
+const (
+	// PasswordCharsetAlphanumeric is the lower and upper case letters and the digits
+	PasswordCharsetAlphanumeric = "alphanumeric"
+	// PasswordCharsetAlphanumericSymbols adds the symbols which are safe in the connection URLs
+	PasswordCharsetAlphanumericSymbols = "alphanumeric-symbols"
+)
+
+const (
+	// DefaultSize is the db size in Megabytes
+	DefaultSize = 100
+	// DefaultShardsCount is the shards count of a non sharded db
+	DefaultShardsCount = 1
+)
+
+// DefaultSnapshotPolicy takes a snapshot every 12 hours if there was at least a single write
+var DefaultSnapshotPolicy = []SnapshotPolicy{{Secs: 43200, Writes: 1}}
+
+// DefaultShardKeyRegex is the Redis Enterprise standard hashing policy
+var DefaultShardKeyRegex = []string{`.*\{(?<tag>.*)\}.*`, `(?<tag>.*)`}
+
+// SetDefaults_RdbcSpec sets the defaults of the unset spec fields
+func SetDefaults_RdbcSpec(spec *RdbcSpec) {
+	if spec.Size == 0 {
+		spec.Size = DefaultSize
+	}
+	if spec.ShardsCount == 0 {
+		spec.ShardsCount = DefaultShardsCount
+	}
+	if spec.ShardsCount > 1 && len(spec.ShardKeyRegex) == 0 {
+		spec.ShardKeyRegex = append([]string{}, DefaultShardKeyRegex...)
+	}
+	if spec.ProxyPolicy == "" {
+		if spec.OSSCluster {
+			spec.ProxyPolicy = ProxyPolicyAllMasterShards
+		} else {
+			spec.ProxyPolicy = ProxyPolicySingle
+		}
+	}
+	if spec.DataPersistence == "" {
+		spec.DataPersistence = DataPersistenceDisabled
+	}
+	if spec.DataPersistence == DataPersistenceAof && spec.AofPolicy == "" {
+		spec.AofPolicy = AofPolicyEverySec
+	}
+	if spec.DataPersistence == DataPersistenceSnapshot && len(spec.SnapshotPolicy) == 0 {
+		spec.SnapshotPolicy = append([]SnapshotPolicy{}, DefaultSnapshotPolicy...)
+	}
+	if spec.EvictionPolicy == "" {
+		spec.EvictionPolicy = EvictionPolicyVolatileLru
+	}
+	if spec.DriftPolicy == "" {
+		spec.DriftPolicy = DriftPolicyCorrect
+	}
+}
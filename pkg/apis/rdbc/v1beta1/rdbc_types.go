@@ -0,0 +1,291 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PasswordAnnotation keeps the plaintext spec.password of the v1alpha1 Rdbc,
+// the operator moves the password to a Secret and removes the annotation
+const PasswordAnnotation = "rdbc.cnative/v1alpha1-password"
+
+// RdbcSpec defines the desired state of Rdbc
+// +k8s:openapi-gen=true
+type RdbcSpec struct {
+	// Name is the Redis Enterprise db name, may be set on creation only
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$
+	Name string `json:"name"`
+	// Size is the db memory limit in Megabytes, defaults to 100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Size int `json:"size,omitempty"`
+	// PasswordSecretRef selects the db password from a Secret in the Rdbc namespace,
+	// a password is generated if it's not set
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// PasswordPolicy sets the length and the charset of the generated passwords,
+	// the unset fields default to the operator wide policy
+	PasswordPolicy *PasswordPolicy `json:"passwordPolicy,omitempty"`
+	// PasswordRotation enables the automatic rotation of the db password
+	PasswordRotation *PasswordRotation `json:"passwordRotation,omitempty"`
+	// Replication enables in-memory replication of the db shards, defaults to false
+	// +optional
+	Replication bool `json:"replication"`
+	// ShardsCount is the number of the db shards, defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=512
+	ShardsCount int `json:"shardsCount,omitempty"`
+	// ShardKeyRegex are the regexes used to extract the hash tag from the keys of a sharded db,
+	// each regex must have a named capturing group called tag, defaults to the Redis Enterprise standard hashing policy
+	ShardKeyRegex []string `json:"shardKeyRegex,omitempty"`
+	// OSSCluster enables the OSS cluster API, defaults to false
+	// +optional
+	OSSCluster bool `json:"ossCluster"`
+	// ProxyPolicy is one of single, all-master-shards or all-nodes,
+	// defaults to all-master-shards for OSS cluster db and to single for any other db
+	// +kubebuilder:validation:Enum=single,all-master-shards,all-nodes
+	ProxyPolicy string `json:"proxyPolicy,omitempty"`
+	// DataPersistence is one of disabled, aof or snapshot, defaults to disabled
+	// +kubebuilder:validation:Enum=disabled,aof,snapshot
+	DataPersistence string `json:"dataPersistence,omitempty"`
+	// AofPolicy is one of appendfsync-every-sec or appendfsync-always,
+	// applies to aof persistence only, defaults to appendfsync-every-sec
+	// +kubebuilder:validation:Enum=appendfsync-every-sec,appendfsync-always
+	AofPolicy string `json:"aofPolicy,omitempty"`
+	// SnapshotPolicy are the snapshot rules, applies to snapshot persistence only,
+	// defaults to a snapshot every 12 hours if there was at least a single write
+	SnapshotPolicy []SnapshotPolicy `json:"snapshotPolicy,omitempty"`
+	// EvictionPolicy is the Redis eviction policy, defaults to volatile-lru
+	// +kubebuilder:validation:Enum=volatile-lru,volatile-lfu,volatile-ttl,volatile-random,allkeys-lru,allkeys-lfu,allkeys-random,noeviction
+	EvictionPolicy string `json:"evictionPolicy,omitempty"`
+	// Modules are the Redis modules loaded by the db, may be set on creation only
+	Modules []RdbcModule `json:"modules,omitempty"`
+	// ClusterRef is the name of the RedisEnterpriseCluster to create the db on,
+	// defaults to the default cluster, may be set on creation only
+	ClusterRef string `json:"clusterRef,omitempty"`
+	// DeletionPolicy is what happens to the db when the Rdbc is deleted: Delete, Retain or Snapshot,
+	// defaults to the operator wide policy
+	// +kubebuilder:validation:Enum=Delete,Retain,Snapshot
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+	// DriftPolicy is what happens when the db settings are changed out of band, e.g. in the Redis Enterprise UI:
+	// Correct applies the spec back to the db, Report lists the drifted fields in the Drifted condition, defaults to Correct
+	// +kubebuilder:validation:Enum=Report,Correct
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+	// AdoptFrom imports an existing db instead of creating a new one,
+	// the spec is applied to the db once adopted
+	AdoptFrom *AdoptFrom `json:"adoptFrom,omitempty"`
+}
+
+// AdoptFrom identifies the existing db to adopt, by uid or by name
+// +k8s:openapi-gen=true
+type AdoptFrom struct {
+	// Uid of the existing db
+	Uid int32 `json:"uid,omitempty"`
+	// Name of the existing db
+	Name string `json:"name,omitempty"`
+	// DryRun reports the changes the spec would make to the db in status.adoption,
+	// the db is not adopted until DryRun is unset
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// PasswordPolicy defines the generated passwords
+// +k8s:openapi-gen=true
+type PasswordPolicy struct {
+	// Length of the generated passwords, 16 to 128
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=128
+	Length int `json:"length,omitempty"`
+	// Charset of the generated passwords, alphanumeric or alphanumeric-symbols
+	// +kubebuilder:validation:Enum=alphanumeric,alphanumeric-symbols
+	Charset string `json:"charset,omitempty"`
+}
+
+// PasswordRotation defines the automatic db password rotation,
+// the rotation may be requested manually by the rdbc.cnative/rotate-password annotation as well
+// +k8s:openapi-gen=true
+type PasswordRotation struct {
+	// Interval is the time between the rotations, e.g. 2160h for 90 days, at least 1h
+	Interval metav1.Duration `json:"interval"`
+}
+
+// RdbcModule is the Redis module loaded by the db
+// +k8s:openapi-gen=true
+type RdbcModule struct {
+	// Name is the module name, e.g. search, ReJSON, timeseries or bf
+	Name string `json:"name"`
+	// Version is the module semantic version, defaults to the latest version installed on the cluster
+	Version string `json:"version,omitempty"`
+	// Args are the module arguments
+	Args string `json:"args,omitempty"`
+}
+
+// SnapshotPolicy takes a snapshot every Secs seconds if there were at least Writes writes
+// +k8s:openapi-gen=true
+type SnapshotPolicy struct {
+	// +kubebuilder:validation:Minimum=1
+	Secs int `json:"secs"`
+	// +kubebuilder:validation:Minimum=0
+	Writes int `json:"writes"`
+}
+
+// RdbcPersistenceStatus is the effective persistence and eviction settings of the db
+// +k8s:openapi-gen=true
+type RdbcPersistenceStatus struct {
+	DataPersistence string           `json:"dataPersistence,omitempty"`
+	AofPolicy       string           `json:"aofPolicy,omitempty"`
+	SnapshotPolicy  []SnapshotPolicy `json:"snapshotPolicy,omitempty"`
+	EvictionPolicy  string           `json:"evictionPolicy,omitempty"`
+}
+
+// RdbcPhase is the lifecycle phase of the Rdbc
+type RdbcPhase string
+
+const (
+	RdbcPhasePending      RdbcPhase = "Pending"
+	RdbcPhaseProvisioning RdbcPhase = "Provisioning"
+	RdbcPhaseReady        RdbcPhase = "Ready"
+	RdbcPhaseFailed       RdbcPhase = "Failed"
+	RdbcPhaseDeleting     RdbcPhase = "Deleting"
+)
+
+// RdbcConditionType is the type of the Rdbc condition
+type RdbcConditionType string
+
+const (
+	// RdbcConditionReady is true when the db is active and the connection Secret is up to date
+	RdbcConditionReady RdbcConditionType = "Ready"
+	// RdbcConditionProvisioning is true while the db is being created or updated
+	RdbcConditionProvisioning RdbcConditionType = "Provisioning"
+	// RdbcConditionDegraded is true when the db doesn't match the spec or the last reconcile failed
+	RdbcConditionDegraded RdbcConditionType = "Degraded"
+	// RdbcConditionDeleting is true while the db is being deleted
+	RdbcConditionDeleting RdbcConditionType = "Deleting"
+	// RdbcConditionClusterUnavailable is true when the Redis Enterprise cluster of the db
+	// is not found or its API configurations can't be loaded
+	RdbcConditionClusterUnavailable RdbcConditionType = "ClusterUnavailable"
+	// RdbcConditionDrifted is true when the db settings were changed out of band and differ from the spec
+	RdbcConditionDrifted RdbcConditionType = "Drifted"
+)
+
+// RdbcCondition describes the state of the Rdbc at a certain point
+// +k8s:openapi-gen=true
+type RdbcCondition struct {
+	Type               RdbcConditionType      `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// RdbcStatus defines the observed state of Rdbc
+// +k8s:openapi-gen=true
+type RdbcStatus struct {
+	// Phase is the lifecycle phase of the Rdbc
+	Phase RdbcPhase `json:"phase,omitempty"`
+	// Conditions are the latest observations of the Rdbc state
+	Conditions []RdbcCondition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent Rdbc generation applied to the db
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// DbUid is the Redis Enterprise db uid
+	DbUid int32 `json:"dbUid,omitempty"`
+	// Endpoint is the db endpoint as host:port
+	Endpoint string `json:"endpoint,omitempty"`
+	// LastSyncTime is the last time a change of the db state was synced into the status
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// MemorySize is the actual db size in Megabytes
+	MemorySize int `json:"memorySize,omitempty"`
+	// PendingAction is the uid of the Redis Enterprise action the db is waiting for
+	PendingAction string `json:"pendingAction,omitempty"`
+	// Persistence is the effective persistence and eviction settings of the db
+	Persistence *RdbcPersistenceStatus `json:"persistence,omitempty"`
+	// LastPasswordRotation is the last time the db password was rotated
+	LastPasswordRotation *metav1.Time `json:"lastPasswordRotation,omitempty"`
+	// Cluster is the RedisEnterpriseCluster the db was created on, empty for the cluster set by the operator env vars
+	Cluster string `json:"cluster,omitempty"`
+	// Adoption is the report of the existing db adoption
+	Adoption *RdbcAdoptionStatus `json:"adoption,omitempty"`
+	// DeletionSnapshot is the db export made by the Snapshot deletion policy
+	DeletionSnapshot *RdbcSnapshotStatus `json:"deletionSnapshot,omitempty"`
+	// Usage is the db usage of the last Redis Enterprise stats interval
+	Usage *RdbcUsageStatus `json:"usage,omitempty"`
+}
+
+// RdbcUsageStatus is the db usage reported by the Redis Enterprise stats
+// +k8s:openapi-gen=true
+type RdbcUsageStatus struct {
+	// UsedMemory is the memory used by the db in bytes
+	UsedMemory int64 `json:"usedMemory"`
+	// OpsPerSec is the rate of the db operations
+	OpsPerSec int64 `json:"opsPerSec"`
+	// ConnectedClients is the number of the client connections
+	ConnectedClients int64 `json:"connectedClients"`
+	// EvictionsPerSec is the rate of the evicted keys, a decimal since the rates below 1 are common, e.g. 250m
+	EvictionsPerSec resource.Quantity `json:"evictionsPerSec"`
+	// LastUpdateTime is the time the usage was read from the cluster
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// RdbcSnapshotStatus is the db export
+// +k8s:openapi-gen=true
+type RdbcSnapshotStatus struct {
+	// ActionUid is the uid of the Redis Enterprise export action
+	ActionUid string `json:"actionUid"`
+	// Location is the storage the db is exported to, without the credentials
+	Location string `json:"location"`
+	// StartTime is the time the export started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the export completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// RdbcAdoptionStatus is the report of the existing db adoption
+// +k8s:openapi-gen=true
+type RdbcAdoptionStatus struct {
+	// DbUid is the uid of the adopted db
+	DbUid int32 `json:"dbUid"`
+	// DbName is the name of the adopted db
+	DbName string `json:"dbName"`
+	// Adopted is true once the operator manages the db, false for the dry-run
+	Adopted bool `json:"adopted"`
+	// Changes are the differences between the spec and the db, applied to the db once adopted
+	Changes []string `json:"changes,omitempty"`
+	// Rejected are the spec changes which can't be applied in place, the db keeps its settings
+	Rejected []string `json:"rejected,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Rdbc is the Schema for the rdbcs API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.cluster"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".status.memorySize",description="DB size in Megabytes"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Rdbc struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RdbcSpec   `json:"spec,omitempty"`
+	Status RdbcStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RdbcList contains a list of Rdbc
+type RdbcList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Rdbc `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Rdbc{}, &RdbcList{})
+}
@@ -0,0 +1,257 @@
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// MaxShardsCount is the max number of shards of a single db
+	MaxShardsCount = 512
+
+	// MinPasswordRotationInterval is the min interval of the password rotation
+	MinPasswordRotationInterval = time.Hour
+
+	// MinPasswordLength and MaxPasswordLength are the length limits of the generated passwords
+	MinPasswordLength = 16
+	MaxPasswordLength = 128
+
+	// MaxDbNameLength is the max length of the Redis Enterprise db name
+	MaxDbNameLength = 63
+
+	shardKeyRegexTag = "(?<tag>"
+)
+
+// dbNameRegexp is the Redis Enterprise db naming rule: letters, digits and hyphens, starting and ending with a letter or a digit
+var dbNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
+var (
+	proxyPolicies    = []string{ProxyPolicySingle, ProxyPolicyAllMasterShards, ProxyPolicyAllNodes}
+	dataPersistences = []string{DataPersistenceDisabled, DataPersistenceAof, DataPersistenceSnapshot}
+	aofPolicies      = []string{AofPolicyEverySec, AofPolicyAlways}
+	deletionPolicies = []string{DeletionPolicyDelete, DeletionPolicyRetain, DeletionPolicySnapshot}
+	driftPolicies    = []string{DriftPolicyReport, DriftPolicyCorrect}
+	passwordCharsets = []string{PasswordCharsetAlphanumeric, PasswordCharsetAlphanumericSymbols}
+	evictionPolicies = []string{
+		EvictionPolicyVolatileLru,
+		EvictionPolicyVolatileLfu,
+		EvictionPolicyVolatileTtl,
+		EvictionPolicyVolatileRandom,
+		EvictionPolicyAllKeysLru,
+		EvictionPolicyAllKeysLfu,
+		EvictionPolicyAllKeysRandom,
+		EvictionPolicyNoEviction,
+	}
+)
+
+// ValidateRdbcSpec validates the defaulted spec
+func ValidateRdbcSpec(spec *RdbcSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else if len(spec.Name) > MaxDbNameLength {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("name"), spec.Name, MaxDbNameLength))
+	} else if !dbNameRegexp.MatchString(spec.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), spec.Name, "may contain only letters, digits and hyphens, and must start and end with a letter or a digit"))
+	}
+	if spec.Size <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), spec.Size, "must be greater than 0"))
+	}
+	allErrs = append(allErrs, validatePassword(spec, fldPath)...)
+	if spec.DeletionPolicy != "" && !containsString(deletionPolicies, spec.DeletionPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deletionPolicy"), spec.DeletionPolicy, deletionPolicies))
+	}
+	if !containsString(driftPolicies, spec.DriftPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("driftPolicy"), spec.DriftPolicy, driftPolicies))
+	}
+	allErrs = append(allErrs, validateAdoptFrom(spec.AdoptFrom, fldPath.Child("adoptFrom"))...)
+	allErrs = append(allErrs, ValidatePasswordPolicy(spec.PasswordPolicy, fldPath.Child("passwordPolicy"))...)
+	allErrs = append(allErrs, validatePasswordRotation(spec.PasswordRotation, fldPath.Child("passwordRotation"))...)
+	if spec.ShardsCount < 1 || spec.ShardsCount > MaxShardsCount {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("shardsCount"), spec.ShardsCount, "must be between 1 and 512"))
+	}
+	if len(spec.ShardKeyRegex) > 0 && spec.ShardsCount == 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("shardKeyRegex"), "may be set only for sharded db, shardsCount must be greater than 1"))
+	}
+	for i, regex := range spec.ShardKeyRegex {
+		if !strings.Contains(regex, shardKeyRegexTag) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("shardKeyRegex").Index(i), regex, "must have a named capturing group called tag"))
+		}
+	}
+	if !containsString(proxyPolicies, spec.ProxyPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("proxyPolicy"), spec.ProxyPolicy, proxyPolicies))
+	}
+	if spec.OSSCluster && spec.ProxyPolicy == ProxyPolicySingle {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("proxyPolicy"), spec.ProxyPolicy, "OSS cluster db requires all-master-shards or all-nodes proxy policy"))
+	}
+	allErrs = append(allErrs, validatePersistence(spec, fldPath)...)
+	allErrs = append(allErrs, validateModules(spec, fldPath)...)
+	return allErrs
+}
+
+// ValidateRdbcSpecUpdate validates the changes of the defaulted spec of the created db,
+// the changes which can't be applied in place are rejected
+func ValidateRdbcSpecUpdate(spec *RdbcSpec, oldSpec *RdbcSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Name != oldSpec.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "can't be changed after the db creation"))
+	}
+	if spec.ClusterRef != oldSpec.ClusterRef {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("clusterRef"), "can't be changed after the db creation"))
+	}
+	if spec.ShardsCount < oldSpec.ShardsCount {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("shardsCount"), spec.ShardsCount, fmt.Sprintf("can't be decreased from %d", oldSpec.ShardsCount)))
+	}
+	if oldSpec.ShardsCount > 1 && spec.ShardsCount > 1 && !equalStrings(spec.ShardKeyRegex, oldSpec.ShardKeyRegex) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("shardKeyRegex"), "can't be changed for a sharded db"))
+	}
+	if !equalModules(spec.Modules, oldSpec.Modules) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("modules"), "can't be changed after the db creation"))
+	}
+	return allErrs
+}
+
+func validateModules(spec *RdbcSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]bool{}
+	for i, module := range spec.Modules {
+		if module.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("modules").Index(i).Child("name"), ""))
+			continue
+		}
+		name := strings.ToLower(module.Name)
+		if names[name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("modules").Index(i).Child("name"), module.Name))
+		}
+		names[name] = true
+	}
+	return allErrs
+}
+
+func validatePersistence(spec *RdbcSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !containsString(dataPersistences, spec.DataPersistence) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("dataPersistence"), spec.DataPersistence, dataPersistences))
+	}
+	if spec.DataPersistence == DataPersistenceAof {
+		if !containsString(aofPolicies, spec.AofPolicy) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("aofPolicy"), spec.AofPolicy, aofPolicies))
+		}
+	} else if spec.AofPolicy != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("aofPolicy"), "may be set only for aof persistence"))
+	}
+	if spec.DataPersistence == DataPersistenceSnapshot {
+		for i, policy := range spec.SnapshotPolicy {
+			if policy.Secs <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("snapshotPolicy").Index(i).Child("secs"), policy.Secs, "must be greater than 0"))
+			}
+			if policy.Writes < 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("snapshotPolicy").Index(i).Child("writes"), policy.Writes, "must not be negative"))
+			}
+		}
+	} else if len(spec.SnapshotPolicy) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("snapshotPolicy"), "may be set only for snapshot persistence"))
+	}
+	if !containsString(evictionPolicies, spec.EvictionPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("evictionPolicy"), spec.EvictionPolicy, evictionPolicies))
+	}
+	return allErrs
+}
+
+// validatePassword validates the password Secret reference
+func validatePassword(spec *RdbcSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ref := spec.PasswordSecretRef
+	if ref == nil {
+		return allErrs
+	}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("passwordSecretRef", "name"), ""))
+	}
+	if ref.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("passwordSecretRef", "key"), ""))
+	}
+	return allErrs
+}
+
+// ValidatePasswordPolicy validates the generated password policy, the unset fields are allowed
+func ValidatePasswordPolicy(policy *PasswordPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	if policy.Length != 0 && (policy.Length < MinPasswordLength || policy.Length > MaxPasswordLength) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("length"), policy.Length,
+			fmt.Sprintf("must be between %d and %d", MinPasswordLength, MaxPasswordLength)))
+	}
+	if policy.Charset != "" && !containsString(passwordCharsets, policy.Charset) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("charset"), policy.Charset, passwordCharsets))
+	}
+	return allErrs
+}
+
+func validateAdoptFrom(adoptFrom *AdoptFrom, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if adoptFrom == nil {
+		return allErrs
+	}
+	if adoptFrom.Uid == 0 && adoptFrom.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "uid or name must be set"))
+	}
+	if adoptFrom.Uid != 0 && adoptFrom.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "may not be set together with uid"))
+	}
+	if adoptFrom.Uid < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("uid"), adoptFrom.Uid, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validatePasswordRotation(rotation *PasswordRotation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rotation == nil {
+		return allErrs
+	}
+	if rotation.Interval.Duration < MinPasswordRotationInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("interval"), rotation.Interval.Duration.String(),
+			"must be at least "+MinPasswordRotationInterval.String()))
+	}
+	return allErrs
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalModules(a []RdbcModule, b []RdbcModule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
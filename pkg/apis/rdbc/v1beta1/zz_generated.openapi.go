@@ -0,0 +1,729 @@
+// +build !ignore_autogenerated
+
+// This file was autogenerated by openapi-gen. Do not edit it manually!
+
+package v1beta1
+
+import (
+	spec "github.com/go-openapi/spec"
+	common "k8s.io/kube-openapi/pkg/common"
+)
+
+func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	return map[string]common.OpenAPIDefinition{
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.AdoptFrom":             schema_pkg_apis_rdbc_v1beta1_AdoptFrom(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.PasswordPolicy":        schema_pkg_apis_rdbc_v1beta1_PasswordPolicy(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.PasswordRotation":      schema_pkg_apis_rdbc_v1beta1_PasswordRotation(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.Rdbc":                  schema_pkg_apis_rdbc_v1beta1_Rdbc(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcAdoptionStatus":    schema_pkg_apis_rdbc_v1beta1_RdbcAdoptionStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcCondition":         schema_pkg_apis_rdbc_v1beta1_RdbcCondition(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcModule":            schema_pkg_apis_rdbc_v1beta1_RdbcModule(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcPersistenceStatus": schema_pkg_apis_rdbc_v1beta1_RdbcPersistenceStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcSnapshotStatus":    schema_pkg_apis_rdbc_v1beta1_RdbcSnapshotStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcSpec":              schema_pkg_apis_rdbc_v1beta1_RdbcSpec(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcStatus":            schema_pkg_apis_rdbc_v1beta1_RdbcStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcUsageStatus":       schema_pkg_apis_rdbc_v1beta1_RdbcUsageStatus(ref),
+		"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.SnapshotPolicy":        schema_pkg_apis_rdbc_v1beta1_SnapshotPolicy(ref),
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_AdoptFrom(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AdoptFrom identifies the existing db to adopt, by uid or by name",
+				Properties: map[string]spec.Schema{
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Uid of the existing db",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the existing db",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun reports the changes the spec would make to the db in status.adoption, the db is not adopted until DryRun is unset",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_PasswordPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PasswordPolicy defines the generated passwords",
+				Properties: map[string]spec.Schema{
+					"length": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Length of the generated passwords, 16 to 128",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"charset": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Charset of the generated passwords, alphanumeric or alphanumeric-symbols",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_PasswordRotation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PasswordRotation defines the automatic db password rotation, the rotation may be requested manually by the rdbc.cnative/rotate-password annotation as well",
+				Properties: map[string]spec.Schema{
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interval is the time between the rotations, e.g. 2160h for 90 days, at least 1h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"interval"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_Rdbc(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Rdbc is the Schema for the rdbcs API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcSpec", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcAdoptionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcAdoptionStatus is the report of the existing db adoption",
+				Properties: map[string]spec.Schema{
+					"dbUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbUid is the uid of the adopted db",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dbName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbName is the name of the adopted db",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adopted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Adopted is true once the operator manages the db, false for the dry-run",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"changes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Changes are the differences between the spec and the db, applied to the db once adopted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"rejected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rejected are the spec changes which can't be applied in place, the db keeps its settings",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"dbUid", "dbName", "adopted"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcCondition describes the state of the Rdbc at a certain point",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcModule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcModule is the Redis module loaded by the db",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the module name, e.g. search, ReJSON, timeseries or bf",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the module semantic version, defaults to the latest version installed on the cluster",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Args are the module arguments",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcPersistenceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcPersistenceStatus is the effective persistence and eviction settings of the db",
+				Properties: map[string]spec.Schema{
+					"dataPersistence": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"aofPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"snapshotPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.SnapshotPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"evictionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.SnapshotPolicy"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcSnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcSnapshotStatus is the db export",
+				Properties: map[string]spec.Schema{
+					"actionUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActionUid is the uid of the Redis Enterprise export action",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"location": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Location is the storage the db is exported to, without the credentials",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the export started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the export completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"actionUid", "location"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcSpec defines the desired state of Rdbc",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the Redis Enterprise db name, may be set on creation only",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the db memory limit in Megabytes, defaults to 100",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"passwordSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordSecretRef selects the db password from a Secret in the Rdbc namespace, a password is generated if it's not set",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"passwordPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordPolicy sets the length and the charset of the generated passwords, the unset fields default to the operator wide policy",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.PasswordPolicy"),
+						},
+					},
+					"passwordRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordRotation enables the automatic rotation of the db password",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.PasswordRotation"),
+						},
+					},
+					"replication": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replication enables in-memory replication of the db shards, defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"shardsCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ShardsCount is the number of the db shards, defaults to 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"shardKeyRegex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ShardKeyRegex are the regexes used to extract the hash tag from the keys of a sharded db, each regex must have a named capturing group called tag, defaults to the Redis Enterprise standard hashing policy",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"ossCluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSSCluster enables the OSS cluster API, defaults to false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"proxyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyPolicy is one of single, all-master-shards or all-nodes, defaults to all-master-shards for OSS cluster db and to single for any other db",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dataPersistence": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DataPersistence is one of disabled, aof or snapshot, defaults to disabled",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"aofPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AofPolicy is one of appendfsync-every-sec or appendfsync-always, applies to aof persistence only, defaults to appendfsync-every-sec",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"snapshotPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SnapshotPolicy are the snapshot rules, applies to snapshot persistence only, defaults to a snapshot every 12 hours if there was at least a single write",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.SnapshotPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"evictionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictionPolicy is the Redis eviction policy, defaults to volatile-lru",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"modules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Modules are the Redis modules loaded by the db, may be set on creation only",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcModule"),
+									},
+								},
+							},
+						},
+					},
+					"clusterRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterRef is the name of the RedisEnterpriseCluster to create the db on, defaults to the default cluster, may be set on creation only",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionPolicy is what happens to the db when the Rdbc is deleted: Delete, Retain or Snapshot, defaults to the operator wide policy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"driftPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftPolicy is what happens when the db settings are changed out of band, e.g. in the Redis Enterprise UI: Correct applies the spec back to the db, Report lists the drifted fields in the Drifted condition, defaults to Correct",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adoptFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdoptFrom imports an existing db instead of creating a new one, the spec is applied to the db once adopted",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.AdoptFrom"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.AdoptFrom", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.PasswordPolicy", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.PasswordRotation", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcModule", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.SnapshotPolicy", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcStatus defines the observed state of Rdbc",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the lifecycle phase of the Rdbc",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest observations of the Rdbc state",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcCondition"),
+									},
+								},
+							},
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent Rdbc generation applied to the db",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dbUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DbUid is the Redis Enterprise db uid",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint is the db endpoint as host:port",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the last time a change of the db state was synced into the status",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"memorySize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemorySize is the actual db size in Megabytes",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"pendingAction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PendingAction is the uid of the Redis Enterprise action the db is waiting for",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"persistence": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Persistence is the effective persistence and eviction settings of the db",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcPersistenceStatus"),
+						},
+					},
+					"lastPasswordRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastPasswordRotation is the last time the db password was rotated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the RedisEnterpriseCluster the db was created on, empty for the cluster set by the operator env vars",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adoption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Adoption is the report of the existing db adoption",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcAdoptionStatus"),
+						},
+					},
+					"deletionSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionSnapshot is the db export made by the Snapshot deletion policy",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcSnapshotStatus"),
+						},
+					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage is the db usage of the last Redis Enterprise stats interval",
+							Ref:         ref("github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcUsageStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcAdoptionStatus", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcCondition", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcPersistenceStatus", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcSnapshotStatus", "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1.RdbcUsageStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_RdbcUsageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RdbcUsageStatus is the db usage reported by the Redis Enterprise stats",
+				Properties: map[string]spec.Schema{
+					"usedMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UsedMemory is the memory used by the db in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"opsPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OpsPerSec is the rate of the db operations",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"connectedClients": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConnectedClients is the number of the client connections",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"evictionsPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictionsPerSec is the rate of the evicted keys, a decimal since the rates below 1 are common, e.g. 250m",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the time the usage was read from the cluster",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"usedMemory", "opsPerSec", "connectedClients", "evictionsPerSec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rdbc_v1beta1_SnapshotPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotPolicy takes a snapshot every Secs seconds if there were at least Writes writes",
+				Properties: map[string]spec.Schema{
+					"secs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"writes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+				Required: []string{"secs", "writes"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
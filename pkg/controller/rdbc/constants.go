@@ -1,14 +1,77 @@
 package rdbc
 
+import "time"
+
 const (
-	// Namespace where redis deployed
-	RedisNS = "REDIS_NS"
+	rdbcFinalizer = "finalizer.rdbc.cnative"
 
-	// Redis Enterprise secret name which contains redis admin username and password
-	RedisCredSecret = "REDIS_CRED_SECRET"
+	// Key and name suffix of the Secret the v1alpha1 spec.password and the rotated passwords are saved to
+	passwordSecretKey    = "password"
+	passwordSecretSuffix = "-password"
 
-	// Redis Service name for API access
-	RedisAPI = "REDIS_API"
+	// Annotations of the db uid and of the RedisEnterpriseCluster the db was created on
+	dbUidAnnotation   = "dbuid"
+	clusterAnnotation = "cluster"
 
-	rdbcFinalizer = "finalizer.rdbc.cnative"
+	// Annotation which requests the db password rotation, removed once the password is rotated
+	rotatePasswordAnnotation = "rdbc.cnative/rotate-password"
+
+	// Annotation of the spec last applied to the db, the Report drift policy applies only the spec fields changed since
+	appliedSpecAnnotation = "rdbc.cnative/applied-spec"
+
+	// Min and max requeue intervals while waiting for the db to become active
+	provisioningMinInterval = 2 * time.Second
+	provisioningMaxInterval = time.Minute
+
+	// Requeue interval while the Redis Enterprise cluster is not found or its configurations can't be loaded
+	clusterRetryInterval = 30 * time.Second
+
+	// Requeue interval while the db exceeds the RdbcQuota of the namespace
+	quotaRetryInterval = time.Minute
+
+	// Env var of the interval the dbs are compared with the spec at, 0 disables the periodic resync
+	resyncIntervalEnv     = "RESYNC_INTERVAL"
+	defaultResyncInterval = 10 * time.Minute
+
+	// Env var of the interval the db usage stats are read at, 0 disables the usage stats
+	usageIntervalEnv     = "USAGE_STATS_INTERVAL"
+	defaultUsageInterval = time.Minute
+
+	// Requeue interval while waiting for the db export
+	exportPollInterval = 10 * time.Second
+
+	// Env var of the operator wide deletion policy, Retain if not set
+	defaultDeletionPolicyEnv = "DEFAULT_DELETION_POLICY"
+
+	// Name suffix of the ConfigMap which records the export of the deleted db
+	deletionSnapshotSuffix = "-deletion-snapshot"
+)
+
+// Rdbc condition reasons
+const (
+	ReasonDbReady            = "DbReady"
+	ReasonProvisioning       = "Provisioning"
+	ReasonProvisionFailed    = "ProvisioningFailed"
+	ReasonDbDeleting         = "DbDeleting"
+	ReasonChangesRejected    = "ChangesNotAppliedInPlace"
+	ReasonRedisApiError      = "RedisApiError"
+	ReasonCreateFailed       = "CreateFailed"
+	ReasonUpdateFailed       = "UpdateFailed"
+	ReasonInvalidSpec        = "InvalidSpec"
+	ReasonUnknownModule      = "UnknownModule"
+	ReasonSecretFailed       = "SecretFailed"
+	ReasonFinalizerFailed    = "FinalizerFailed"
+	ReasonClusterNotFound    = "ClusterNotFound"
+	ReasonClusterConfigError = "ClusterConfigError"
+	ReasonClusterAvailable   = "ClusterAvailable"
+	ReasonPasswordFailed     = "PasswordSecretFailed"
+	ReasonRotationFailed     = "PasswordRotationFailed"
+	ReasonAdoptionFailed     = "AdoptionFailed"
+	ReasonAdoptionDryRun     = "AdoptionDryRun"
+	ReasonAdoptionRefused    = "AdoptionRefused"
+	ReasonRestoreInProgress  = "RestoreInProgress"
+	ReasonQuotaExceeded      = "QuotaExceeded"
+	ReasonNoDrift            = "NoDrift"
+	ReasonDriftDetected      = "DriftDetected"
+	ReasonDriftCorrected     = "DriftCorrected"
 )
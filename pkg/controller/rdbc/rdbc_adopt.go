@@ -0,0 +1,179 @@
+package rdbc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// adoptDb imports the existing db set by spec.adoptFrom, the Rdbc is annotated with the db uid
+// and the db is managed as any other db from then on.
+// In the dry-run the changes the spec would make are reported in status.adoption
+// and the result to stop the reconcile is returned.
+// The spec defaults may differ from the db settings, thus the adoption which would shrink the db
+// or turn off its replication or persistence is refused until the spec sets the db values.
+func (r *ReconcileRdbc) adoptDb(rdbc *rdbcv1beta1.Rdbc, password string, redis redisenterprise.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster) (*reconcile.Result, error) {
+	adoptFrom := rdbc.Spec.AdoptFrom
+	if adoptFrom == nil {
+		return nil, nil
+	}
+	// The db is already created or adopted
+	if _, ok := rdbc.Annotations[dbUidAnnotation]; ok {
+		return nil, nil
+	}
+	redisDb, err := findDb(adoptFrom, redis)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkDbNotManaged(rdbc, redisDb.Uid, cluster); err != nil {
+		return nil, err
+	}
+	update, rejected := diffRdbcSpec(desiredRdbcSpec(rdbc), password, redisDb)
+	destructive := destructiveChanges(redisDb, update)
+	rejected = append(rejected, destructive...)
+	adoption := &rdbcv1beta1.RdbcAdoptionStatus{
+		DbUid:    redisDb.Uid,
+		DbName:   redisDb.Name,
+		Adopted:  !adoptFrom.DryRun,
+		Changes:  describeBdbUpdate(redisDb, update),
+		Rejected: rejected,
+	}
+	if adoptFrom.DryRun {
+		log.Info(fmt.Sprintf("adoption dry-run of dbid: %d, changes: %v, rejected: %v", redisDb.Uid, adoption.Changes, rejected),
+			"Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+		rdbc.Status.Adoption = adoption
+		// The spec change which turns the dry-run off triggers a new reconcile
+		return &reconcile.Result{}, r.setRdbcAdoptionDryRun(rdbc, redisDb)
+	}
+	if len(destructive) > 0 {
+		adoption.Adopted = false
+		rdbc.Status.Adoption = adoption
+		err := fmt.Errorf("adoption of dbid: %d refused, set the spec to the db settings: %s", redisDb.Uid, strings.Join(destructive, "; "))
+		log.Info(err.Error(), "Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+		// The spec change triggers a new reconcile
+		return &reconcile.Result{}, r.setRdbcError(rdbc, ReasonAdoptionRefused, err)
+	}
+	log.Info(fmt.Sprintf("adopting dbid: %d", redisDb.Uid), "Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+	// Unlike the new db, the adopted db is kept if the annotation fails
+	setDbAnnotations(rdbc, redisDb.Uid, cluster)
+	if err := r.updateRdbc(rdbc); err != nil {
+		return nil, fmt.Errorf("failed to annotate Rdbc with the adopted dbid: %d: %v", redisDb.Uid, err)
+	}
+	rdbc.Status.Adoption = adoption
+	rdbc.Status.Cluster = clusterName(cluster)
+	if err := r.updateRdbcStatus(rdbc); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// findDb returns the db to adopt by uid or by name
+func findDb(adoptFrom *rdbcv1beta1.AdoptFrom, redis redisenterprise.Client) (*redisenterprise.Bdb, error) {
+	if adoptFrom.Uid != 0 {
+		redisDb, err := redis.GetBdb(adoptFrom.Uid)
+		if redisenterprise.IsNotFound(err) {
+			return nil, fmt.Errorf("db to adopt not found, dbid: %d", adoptFrom.Uid)
+		}
+		return redisDb, err
+	}
+	bdbs, err := redis.ListBdbs()
+	if err != nil {
+		return nil, err
+	}
+	var found *redisenterprise.Bdb
+	for i := range bdbs {
+		if bdbs[i].Name != adoptFrom.Name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("multiple dbs named %s, adopt by uid", adoptFrom.Name)
+		}
+		found = &bdbs[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("db to adopt not found, name: %s", adoptFrom.Name)
+	}
+	return found, nil
+}
+
+// checkDbNotManaged returns an error if another Rdbc manages the db
+func (r *ReconcileRdbc) checkDbNotManaged(rdbc *rdbcv1beta1.Rdbc, dbUid int32, cluster *rdbcv1alpha1.RedisEnterpriseCluster) error {
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := r.client.List(context.TODO(), &client.ListOptions{}, rdbcs); err != nil {
+		return err
+	}
+	for _, other := range rdbcs.Items {
+		if other.Namespace == rdbc.Namespace && other.Name == rdbc.Name {
+			continue
+		}
+		if other.Annotations[dbUidAnnotation] == fmt.Sprint(dbUid) && other.Annotations[clusterAnnotation] == clusterName(cluster) {
+			return fmt.Errorf("dbid: %d is already managed by Rdbc %s/%s", dbUid, other.Namespace, other.Name)
+		}
+	}
+	return nil
+}
+
+// destructiveChanges returns the changes of the update which may lose the db data
+func destructiveChanges(redisDb *redisenterprise.Bdb, update *redisenterprise.Bdb) []string {
+	if update == nil {
+		return nil
+	}
+	var destructive []string
+	if update.MemorySize != 0 && update.MemorySize < redisDb.MemorySize {
+		destructive = append(destructive, fmt.Sprintf("size can't be decreased from %d to %d on adoption",
+			redisenterprise.BytesToMegabytes(redisDb.MemorySize), redisenterprise.BytesToMegabytes(update.MemorySize)))
+	}
+	if update.Replication != nil && !*update.Replication && redisenterprise.BoolValue(redisDb.Replication) {
+		destructive = append(destructive, "replication can't be disabled on adoption")
+	}
+	if update.DataPersistence == rdbcv1beta1.DataPersistenceDisabled && redisDb.DataPersistence != "" {
+		destructive = append(destructive, fmt.Sprintf("dataPersistence can't be disabled on adoption, the db uses: %s", redisDb.DataPersistence))
+	}
+	return destructive
+}
+
+// describeBdbUpdate returns the human readable changes of the update made by diffRdbcSpec,
+// the password is reported without the values
+func describeBdbUpdate(redisDb *redisenterprise.Bdb, update *redisenterprise.Bdb) []string {
+	if update == nil {
+		return nil
+	}
+	var changes []string
+	if update.MemorySize != 0 {
+		changes = append(changes, fmt.Sprintf("size: %d -> %d", redisenterprise.BytesToMegabytes(redisDb.MemorySize), redisenterprise.BytesToMegabytes(update.MemorySize)))
+	}
+	if update.Password != "" {
+		changes = append(changes, "password: set from spec.passwordSecretRef")
+	}
+	if update.Replication != nil {
+		changes = append(changes, fmt.Sprintf("replication: %t -> %t", redisenterprise.BoolValue(redisDb.Replication), *update.Replication))
+	}
+	if update.ShardsCount != 0 {
+		changes = append(changes, fmt.Sprintf("shardsCount: %d -> %d", redisDb.ShardsCount, update.ShardsCount))
+	}
+	if update.OSSCluster != nil {
+		changes = append(changes, fmt.Sprintf("ossCluster: %t -> %t", redisenterprise.BoolValue(redisDb.OSSCluster), *update.OSSCluster))
+	}
+	if update.ProxyPolicy != "" {
+		changes = append(changes, fmt.Sprintf("proxyPolicy: %s -> %s", redisDb.ProxyPolicy, update.ProxyPolicy))
+	}
+	if update.DataPersistence != "" {
+		changes = append(changes, fmt.Sprintf("dataPersistence: %s -> %s", redisDb.DataPersistence, update.DataPersistence))
+	}
+	if update.AofPolicy != "" {
+		changes = append(changes, fmt.Sprintf("aofPolicy: %s -> %s", redisDb.AofPolicy, update.AofPolicy))
+	}
+	if update.SnapshotPolicy != nil {
+		changes = append(changes, fmt.Sprintf("snapshotPolicy: %v -> %v", redisDb.SnapshotPolicy, update.SnapshotPolicy))
+	}
+	if update.EvictionPolicy != "" {
+		changes = append(changes, fmt.Sprintf("evictionPolicy: %s -> %s", redisDb.EvictionPolicy, update.EvictionPolicy))
+	}
+	return changes
+}
@@ -1,63 +1,44 @@
 package rdbc
 
 import (
-	"bytes"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math/rand"
-	"net/http"
 	"time"
-	"github.com/google/uuid"
-)
-
-type RedisDb struct {
-	Uid        int32  `json:"uid"`
-	Name       string `json:"name"`
-	Type       string `json:"type"`
-	MemorySize int    `json:"memory_size"`
-	Password   string `json:"authentication_redis_pass"`
-	endpoint   string
-}
 
-func init() {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-}
-
-type RedisDbApi struct {
-	Response map[string]interface{} `json:"-"`
-}
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+)
 
-func NewRedisDb(dbName string, size int, password string, redis *RedisConfig) (*RedisDb, error) {
-	db := new(RedisDb)
-	dbId, err := redis.getUniqDbId()
+func NewRedisDb(spec *rdbcv1beta1.RdbcSpec, password string, redis redisenterprise.Client) (*redisenterprise.Bdb, error) {
+	db := new(redisenterprise.Bdb)
+	dbId, err := getUniqDbId(redis)
 	if err != nil {
 		log.Error(err, "wasn't able to generate unique BD ID")
 		return nil, err
 	}
-	// Set password if it's not set by user
-	if password == "" {
-		passUuid, err := uuid.NewUUID()
-		if err != nil {
-			log.Error(err, "failed to generate password")
-			return nil, err
-		}
-		password = passUuid.String()[0:8]
-	}
 	db.Uid = dbId
-	db.Name = dbName
+	db.Name = spec.Name
 	db.Password = password
 	// As for now, the Reids DB operator will support only Redis DB creation
 	db.Type = "redis"
 	// User set DB size in Megabytes, API uses memory size in bytes
-	db.MemorySize = size * 1024 * 1024
+	db.MemorySize = redisenterprise.MegabytesToBytes(spec.Size)
+	db.Replication = redisenterprise.Bool(spec.Replication)
+	db.ShardsCount = spec.ShardsCount
+	db.Sharding = redisenterprise.Bool(spec.ShardsCount > 1)
+	db.ShardKeyRegex = shardKeyRegex(spec.ShardKeyRegex)
+	db.OSSCluster = redisenterprise.Bool(spec.OSSCluster)
+	db.ProxyPolicy = spec.ProxyPolicy
+	db.DataPersistence = spec.DataPersistence
+	db.AofPolicy = spec.AofPolicy
+	db.SnapshotPolicy = snapshotPolicy(spec.SnapshotPolicy)
+	db.EvictionPolicy = spec.EvictionPolicy
 	return db, nil
 }
 
-func (redis *RedisConfig) LoadRedisDb(dbId int32) (*RedisDb, error) {
-	rdb := &RedisDb{Uid: dbId}
-	err := redis.GetDb(rdb)
+func LoadRedisDb(dbId int32, redis redisenterprise.Client) (*redisenterprise.Bdb, error) {
+	rdb, err := redis.GetBdb(dbId)
 	if err != nil {
 		log.Error(err, fmt.Sprintf("wasn't able to get db, dbid: %d", dbId))
 		return nil, err
@@ -65,7 +46,7 @@ func (redis *RedisConfig) LoadRedisDb(dbId int32) (*RedisDb, error) {
 	return rdb, nil
 }
 
-func (redis *RedisConfig) getUniqDbId() (int32, error) {
+func getUniqDbId(redis redisenterprise.Client) (int32, error) {
 	// Init random
 	rand.Seed(time.Now().UnixNano())
 	// Will try to generate 3 times uniq ID for DB
@@ -76,7 +57,7 @@ func (redis *RedisConfig) getUniqDbId() (int32, error) {
 	// so I'll generate 3 times random ID and check if such a DB already exists
 	for i := 0; i < 3; i++ {
 		dbId := rand.Int31()
-		exists, err := redis.CheckIfDbExists(dbId)
+		exists, err := CheckIfDbExists(dbId, redis)
 		if err != nil {
 			// Error during getting the DB, will stop the loop and return error
 			log.Error(err, "error checking dbid uniqueness")
@@ -93,115 +74,84 @@ func (redis *RedisConfig) getUniqDbId() (int32, error) {
 
 }
 
-func (redis *RedisConfig) CheckIfDbExists(dbId int32) (bool, error) {
-	resp, err := redis.execApiRequest(fmt.Sprintf("%v/v1/bdbs/%v", redis.APIUrl, dbId), "GET", nil)
+func CheckIfDbExists(dbId int32, redis redisenterprise.Client) (bool, error) {
+	_, err := redis.GetBdb(dbId)
+	if redisenterprise.IsNotFound(err) {
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
-	if resp.StatusCode == 404 {
-		return false, nil
-	}
 	return true, nil
 }
 
-func (redis *RedisConfig) CreateDb(rdb *RedisDb) error {
-	// Compose URL
-	url := redis.APIUrl + "/v1/bdbs"
-	// Marshal request body
-	b, err := json.Marshal(rdb)
-	if err != nil {
-		return fmt.Errorf("failed to Marshal RedisDb for DB request: %s", rdb.Name)
-	}
-	// Exec request and get response
-	resp, err := redis.execApiRequest(url, "POST", b)
-	if err != nil {
-		log.Error(err, "failed execute request POST request ")
-		return fmt.Errorf("failed execute POST request for url: %s", url)
-	}
-	bodyText, err := ioutil.ReadAll(resp.Body)
-	// Assume any code bellow 299 is valid
-	if resp.StatusCode > 299 {
-		return fmt.Errorf("bad status code: %d, body: %s", resp.StatusCode, bodyText)
+func DeleteDb(dbId int32, redis redisenterprise.Client) error {
+	err := redis.DeleteBdb(dbId)
+	if redisenterprise.IsNotFound(err) {
+		log.Info(fmt.Sprintf("dbid: %d doesn't exists in cluster, asuming it was deleted either manually or by finilizer but the CR wasn't update properly", dbId))
+		// if db not exists, assume it was delete manually,
+		// and proceed normally with the request
+		return nil
 	}
-	return nil
+	return err
 }
 
-func (redis *RedisConfig) GetDb(rdb *RedisDb) error {
-
-	// Compose URL
-	url := fmt.Sprintf("%v/v1/bdbs/%v", redis.APIUrl, rdb.Uid)
-	// Exec request
-	resp, err := redis.execApiRequest(url, "GET", nil)
-
-	if err != nil {
-		return fmt.Errorf("failed to executed GET request for url: %s", url)
+// dbEndpoint returns the first endpoint of the db as host:port
+func dbEndpoint(rdb *redisenterprise.Bdb) string {
+	if len(rdb.Endpoints) < 1 {
+		return ""
 	}
-	bodyText, err := ioutil.ReadAll(resp.Body)
-	// Assume any code bellow 299 is valid
-	if resp.StatusCode > 299 {
-		return fmt.Errorf("bad status code: %d, body: %s", resp.StatusCode, bodyText)
+	return fmt.Sprintf("%s:%d", rdb.Endpoints[0].DnsName, rdb.Endpoints[0].Port)
+}
+
+// shardKeyRegex converts the spec regexes to the API shard key regexes
+func shardKeyRegex(regexes []string) []redisenterprise.ShardKeyRegex {
+	var keyRegexes []redisenterprise.ShardKeyRegex
+	for _, regex := range regexes {
+		keyRegexes = append(keyRegexes, redisenterprise.ShardKeyRegex{Regex: regex})
 	}
-	redisDbApi := RedisDbApi{}
-	err = json.Unmarshal([]byte(string(bodyText)), &redisDbApi.Response)
-	if err != nil {
-		return fmt.Errorf("error while unmarshalling response")
+	return keyRegexes
+}
+
+func equalShardKeyRegex(regexes []string, keyRegexes []redisenterprise.ShardKeyRegex) bool {
+	if len(regexes) != len(keyRegexes) {
+		return false
 	}
-	rdb.Name = redisDbApi.Response["name"].(string)
-	rdb.MemorySize = int(redisDbApi.Response["memory_size"].(float64) / 1024 / 1024)
-	rdb.Type = redisDbApi.Response["type"].(string)
-	rdb.Password = redisDbApi.Response["authentication_redis_pass"].(string)
-	if endpoints, ok := redisDbApi.Response["endpoints"].([]interface{}); ok {
-		if len(endpoints) < 1 {
-			return fmt.Errorf("enpoints list is empty for DB: %v", rdb.Uid)
+	for i := range regexes {
+		if regexes[i] != keyRegexes[i].Regex {
+			return false
 		}
-		ep := endpoints[0].(map[string]interface{})
-		rdb.endpoint = fmt.Sprintf("%s:%v", ep["dns_name"].(string), ep["port"].(float64))
-		log.Info(fmt.Sprintf("db endpoint %s", rdb.endpoint))
-	} else {
-		return fmt.Errorf("error while getting endpoints from response")
 	}
-	return nil
+	return true
 }
 
-func (redis *RedisConfig) execApiRequest(url string, method string, body []byte) (response *http.Response, err error) {
-	log.Info(fmt.Sprintf("get DB Url: %s", url))
-	client := &http.Client{}
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
-	if err != nil {
-		log.Error(err, "Error during composing new http request", "url", url)
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	// Set Auth
-	req.SetBasicAuth(redis.Username, redis.Password)
-	// Exec request
-	response, err = client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to executed %s request for url: %s", method, url)
+// snapshotPolicy converts the spec snapshot policy to the API snapshot policy
+func snapshotPolicy(policies []rdbcv1beta1.SnapshotPolicy) []redisenterprise.SnapshotPolicy {
+	var snapshotPolicies []redisenterprise.SnapshotPolicy
+	for _, policy := range policies {
+		snapshotPolicies = append(snapshotPolicies, redisenterprise.SnapshotPolicy{Secs: policy.Secs, Writes: policy.Writes})
 	}
-	return
+	return snapshotPolicies
 }
 
-func (redis *RedisConfig) DeleteDb(dbId int32) error {
-
-	// Check if DB exists in the cluster
-
-	dBExists, err := redis.CheckIfDbExists(dbId)
-	if !dBExists {
-		log.Error(err, fmt.Sprintf("dbid: %s doesn't exists in cluster, asuming it was deleted either manually or by finilizer but the CR wasn't update properly", dbId))
-		// if db not exists, assume it was delete manually,
-		// and proceed normally with the request
-		return nil
+func equalSnapshotPolicy(policies []rdbcv1beta1.SnapshotPolicy, snapshotPolicies []redisenterprise.SnapshotPolicy) bool {
+	if len(policies) != len(snapshotPolicies) {
+		return false
 	}
-	// Compose URL
-	url := fmt.Sprintf("%v/v1/bdbs/%v", redis.APIUrl, dbId)
-	resp, err := redis.execApiRequest(url, "DELETE", nil)
-	if err != nil {
-		return fmt.Errorf("failed to executed DELETE request for url: %s", url)
+	for i := range policies {
+		if policies[i].Secs != snapshotPolicies[i].Secs || policies[i].Writes != snapshotPolicies[i].Writes {
+			return false
+		}
 	}
-	// Assume any code bellow 299 is valid
-	if resp.StatusCode > 299 {
-		return fmt.Errorf("bad status code: %d, for DELETE request: %s", resp.StatusCode, url)
+	return true
+}
+
+// mergeBdb sets the fields of the update on the db, the same way the API applies the update
+func mergeBdb(rdb *redisenterprise.Bdb, update *redisenterprise.Bdb) error {
+	b, err := json.Marshal(update)
+	if err != nil {
+		return err
 	}
-	return nil
+	return json.Unmarshal(b, rdb)
 }
+
@@ -3,102 +3,81 @@ package rdbc
 import (
 	"context"
 	"fmt"
-	corev1 "k8s.io/api/core/v1"
-	"os"
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
-type RedisConfig struct {
-	Username   string
-	Password   string
-	APIUrl     string
-	Namespace  string
-	CredSecret string
+// clusterNotFoundError is returned when the Rdbc doesn't target any existing cluster
+type clusterNotFoundError struct {
+	message string
 }
 
-func (r *ReconcileRdbc) setRedisConfigs() (*RedisConfig, error) {
-	redisConfig := &RedisConfig{}
-	// Get Redis Credentials Secret name
-	redisCredSecretName, err := GetRedisCredSecretName()
-	if err != nil {
-		log.Error(err, "Failed to get Redis Credential Secret")
-		os.Exit(1)
+func (e *clusterNotFoundError) Error() string {
+	return e.message
+}
+
+// resolveCluster returns the RedisEnterpriseCluster the Rdbc targets,
+// nil stands for the cluster set by the operator env vars.
+// An existing db stays on the cluster it was created on, a new db goes to
+// the spec.clusterRef cluster, or to the default cluster if clusterRef is not set.
+func (r *ReconcileRdbc) resolveCluster(rdbc *rdbcv1beta1.Rdbc) (*rdbcv1alpha1.RedisEnterpriseCluster, error) {
+	var clusterName string
+	if _, ok := rdbc.Annotations[dbUidAnnotation]; ok {
+		// The dbs created before the clusters were introduced have no cluster annotation
+		clusterName = rdbc.Annotations[clusterAnnotation]
+	} else if rdbc.Spec.ClusterRef != "" {
+		clusterName = rdbc.Spec.ClusterRef
+	} else {
+		return r.defaultCluster()
 	}
-	// Get Redis Namespace
-	redisNamespace, err := GetRedisNamespace()
-	if err != nil {
-		log.Error(err, "Failed to get Redis namespace")
-		os.Exit(1)
+	if clusterName == "" {
+		return nil, nil
 	}
-	// Get Redis API url
-	redisServiceName, err := GetRedisApiUrl()
-	if err != nil {
-		log.Error(err, "Failed to get Redis API URL")
-		os.Exit(1)
+	cluster := &rdbcv1alpha1.RedisEnterpriseCluster{}
+	if err := r.client.Get(context.TODO(), client.ObjectKey{Name: clusterName}, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &clusterNotFoundError{message: fmt.Sprintf("RedisEnterpriseCluster %s not found", clusterName)}
+		}
+		return nil, err
 	}
-
-	redisConfig.Namespace = redisNamespace
-	redisConfig.CredSecret = redisCredSecretName
-	redisConfig.APIUrl = redisServiceName
-	// Set Redis Credentials
-	err = r.setRedisCreds(redisConfig)
-	if err != nil {
-		log.Error(err, "Failed to set Redis credentials configs")
-		os.Exit(1)
-	}
-
-	return redisConfig, nil
+	return cluster, nil
 }
 
-func (r *ReconcileRdbc) setRedisCreds(redisConfig *RedisConfig) error {
-	redisSecret := &corev1.Secret{}
-	err := r.client.Get(
-		context.TODO(),
-		client.ObjectKey{Name: redisConfig.CredSecret, Namespace: redisConfig.Namespace},
-		redisSecret)
-
-	if err != nil {
-		log.Error(err, "Failed to get Redis Credentials Secret")
-		os.Exit(1)
+// defaultCluster returns the RedisEnterpriseCluster marked as default,
+// or nil if there is no default cluster and the operator env vars set the Redis API
+func (r *ReconcileRdbc) defaultCluster() (*rdbcv1alpha1.RedisEnterpriseCluster, error) {
+	clusters := &rdbcv1alpha1.RedisEnterpriseClusterList{}
+	if err := r.client.List(context.TODO(), &client.ListOptions{}, clusters); err != nil {
+		return nil, err
 	}
-	if _, ok := redisSecret.Data["password"]; ok {
-		redisConfig.Password = string(redisSecret.Data["password"])
-	} else {
-		return fmt.Errorf("failed to get a passwrod from secrets: %v in namespace: %v",
-			redisConfig.CredSecret,
-			redisConfig.Namespace)
+	var defaults []string
+	var cluster *rdbcv1alpha1.RedisEnterpriseCluster
+	for i := range clusters.Items {
+		if clusters.Items[i].Spec.Default {
+			cluster = &clusters.Items[i]
+			defaults = append(defaults, cluster.Name)
+		}
 	}
-	if _, ok := redisSecret.Data["username"]; ok {
-		redisConfig.Username = string(redisSecret.Data["username"])
-	} else {
-		return fmt.Errorf("failed to get a username from secrets: %v in namespace: %v",
-			redisConfig.CredSecret,
-			redisConfig.Namespace)
+	if len(defaults) > 1 {
+		return nil, &clusterNotFoundError{message: fmt.Sprintf("multiple default RedisEnterpriseClusters: %s, set spec.clusterRef", strings.Join(defaults, ", "))}
 	}
-	return nil
-}
-
-
-func GetRedisCredSecretName() (string, error) {
-	redisCredSecret, found := os.LookupEnv(RedisCredSecret)
-	if !found {
-		return "", fmt.Errorf("%s must be set", RedisCredSecret)
+	if cluster != nil {
+		return cluster, nil
 	}
-	return redisCredSecret, nil
-}
-
-func GetRedisNamespace() (string, error) {
-	redisCredSecret, found := os.LookupEnv(RedisNS)
-	if !found {
-		return "", fmt.Errorf("%s must be set", RedisNS)
+	if !redisconfig.IsEnvConfigured() {
+		return nil, &clusterNotFoundError{message: "no default RedisEnterpriseCluster, set spec.clusterRef"}
 	}
-	return redisCredSecret, nil
+	return nil, nil
 }
 
-func GetRedisApiUrl() (string, error) {
-	redisAPIUrl, found := os.LookupEnv(RedisAPI)
-	if !found {
-		return "", fmt.Errorf("%s must be set", RedisAPI)
+// clusterName returns the name recorded for the cluster, empty for the cluster set by the operator env vars
+func clusterName(cluster *rdbcv1alpha1.RedisEnterpriseCluster) string {
+	if cluster == nil {
+		return ""
 	}
-	return redisAPIUrl, nil
+	return cluster.Name
 }
@@ -4,42 +4,123 @@ import (
 	"context"
 	"fmt"
 	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/metrics"
+	"github.com/rdbc-operator/pkg/passwordgen"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"github.com/rdbc-operator/pkg/redisenterprise"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"os"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"reflect"
 	"strconv"
+	"time"
 )
 
 var log = logf.Log.WithName("controller_rdbc")
 
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	// The clients are shared with the backup and the restore controllers, the Secret watches below invalidate them
+	clients := redisconfig.SharedClientCache(mgr.GetClient())
+	passwordPolicy, err := passwordgen.FromEnv()
+	if err != nil {
+		return err
+	}
+	deletionPolicy, err := deletionPolicyFromEnv()
+	if err != nil {
+		return err
+	}
+	resyncInterval, err := resyncIntervalFromEnv()
+	if err != nil {
+		return err
+	}
+	usageInterval, err := usageIntervalFromEnv()
+	if err != nil {
+		return err
+	}
+	// The managed dbs gauges are computed from the cached Rdbcs
+	if err := metrics.RegisterRdbcCollector(mgr.GetClient()); err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, clients, passwordPolicy, deletionPolicy, resyncInterval, usageInterval), clients)
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileRdbc{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+func newReconciler(mgr manager.Manager, clients *redisconfig.ClientCache, passwordPolicy rdbcv1beta1.PasswordPolicy, deletionPolicy string, resyncInterval time.Duration, usageInterval time.Duration) reconcile.Reconciler {
+	return &ReconcileRdbc{
+		client:                mgr.GetClient(),
+		scheme:                mgr.GetScheme(),
+		redisClient:           clients.Get,
+		passwordPolicy:        passwordPolicy,
+		defaultDeletionPolicy: deletionPolicy,
+		resyncInterval:        resyncInterval,
+		usageInterval:         usageInterval,
+	}
 }
 
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+func add(mgr manager.Manager, r reconcile.Reconciler, clients *redisconfig.ClientCache) error {
 	// Create a new controller
 	c, err := controller.New("rdbc-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
 
-	// Watch for changes to primary resource Rdbc
-	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.Rdbc{}}, &handler.EnqueueRequestForObject{})
+	// Watch for changes to primary resource Rdbc, the status updates are ignored,
+	// thus the status writes of the reconcile don't trigger another reconcile
+	err = c.Watch(&source.Kind{Type: &rdbcv1beta1.Rdbc{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return rdbcChanged(e.MetaOld, e.MetaNew)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for RedisEnterpriseClusters, the Rdbcs with unavailable cluster are reconciled once it's created or fixed
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RedisEnterpriseCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			return rdbcsWithUnavailableCluster(mgr.GetClient())
+		}),
+	}, predicate.Funcs{
+		// The status updates of the cluster probes are ignored
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the RdbcQuotas, the Rdbcs blocked by the quota are retried once it's raised
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcQuota{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			return rdbcsOverQuota(mgr.GetClient(), a.Meta.GetNamespace())
+		}),
+	}, predicate.Funcs{
+		// The usage updates of the quota status are ignored
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the RdbcRestores, the spec changes held by a running restore are applied once it finishes
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcRestore{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: rdbcOfRestore})
 	if err != nil {
 		return err
 	}
@@ -47,8 +128,35 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Watch for changes to Secret
 	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
-		OwnerType:    &rdbcv1alpha1.Rdbc{},
+		OwnerType:    &rdbcv1beta1.Rdbc{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the password Secrets
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			return rdbcsReferencingPassword(mgr.GetClient(), a.Meta.GetNamespace(), a.Meta.GetName())
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the Secrets and ConfigMaps of the clusters configurations,
+	// the cached API clients are reloaded on change
+	clusterConfigChanged := handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+		if !clients.InvalidateRef(a.Meta.GetNamespace(), a.Meta.GetName()) {
+			return nil
+		}
+		return rdbcsWithUnavailableCluster(mgr.GetClient())
 	})
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: clusterConfigChanged})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: clusterConfigChanged})
 	if err != nil {
 		return err
 	}
@@ -65,76 +173,281 @@ type ReconcileRdbc struct {
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
 	scheme *runtime.Scheme
+	// redisClient returns the Redis Enterprise API client of the cluster
+	redisClient func(cluster *rdbcv1alpha1.RedisEnterpriseCluster) (redisenterprise.Client, error)
+	// passwordPolicy is the operator wide policy of the generated passwords
+	passwordPolicy rdbcv1beta1.PasswordPolicy
+	// defaultDeletionPolicy is the deletion policy of the Rdbcs without spec.deletionPolicy
+	defaultDeletionPolicy string
+	// resyncInterval is the interval the dbs are compared with the spec at, the out of band changes
+	// are otherwise noticed on the next Rdbc event only
+	resyncInterval time.Duration
+	// usageInterval is the interval the db usage stats are read into status.usage at
+	usageInterval time.Duration
 }
 
 func (r *ReconcileRdbc) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling Rdbc")
-	redis, err := r.setRedisConfigs()
-	if err != nil {
-		log.Error(err, "Failed to init Redis Configurations")
-		os.Exit(1)
-	}
 	// Fetch the Rdbc
-	rdbc := &rdbcv1alpha1.Rdbc{}
-	err = r.client.Get(context.TODO(), request.NamespacedName, rdbc)
+	rdbc := &rdbcv1beta1.Rdbc{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, rdbc)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
+	// The status as read, the status write is skipped if the reconcile changed nothing but the sync time
+	observed := rdbc.Status.DeepCopy()
+
+	// Resolve the Redis Enterprise cluster of the db
+	cluster, err := r.resolveCluster(rdbc)
+	if _, ok := err.(*clusterNotFoundError); ok {
+		reqLogger.Error(err, "Redis Enterprise cluster not found")
+		if rdbc.GetDeletionTimestamp() != nil {
+			// The Rdbc has no db to clean up, its deletion isn't blocked by the missing cluster
+			if _, ok := rdbc.Annotations[dbUidAnnotation]; !ok {
+				if contains(rdbc.GetFinalizers(), rdbcFinalizer) {
+					reqLogger.Info("Removing Finalizer of the Rdbc, no db was created")
+					if err := r.removeFinalizerAndUpdateCR(rdbc); err != nil {
+						return reconcile.Result{}, err
+					}
+				}
+				return reconcile.Result{}, nil
+			}
+			// The db can't be deleted until the cluster is back, the finalizer is kept
+			// unless it's removed by hand to leave the db as is on the cluster
+			err = fmt.Errorf("%v, the db can't be deleted, remove the %s finalizer to delete the Rdbc and leave the db", err, rdbcFinalizer)
+		}
+		if err := r.setRdbcClusterUnavailable(rdbc, ReasonClusterNotFound, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+			return reconcile.Result{}, err
+		}
+		// The Rdbc is reconciled once a RedisEnterpriseCluster is created or changed, or on the next retry
+		return reconcile.Result{RequeueAfter: clusterRetryInterval}, nil
+	} else if err != nil {
+		reqLogger.Error(err, "Failed to resolve Redis Enterprise cluster")
+		return reconcile.Result{}, err
+	}
+	redis, err := r.redisClient(cluster)
+	if err != nil {
+		reqLogger.Error(err, "Failed to init Redis Configurations")
+		if err := r.setRdbcClusterUnavailable(rdbc, ReasonClusterConfigError, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+			return reconcile.Result{}, err
+		}
+		// The configurations are reloaded on the Secret change or on the next retry
+		return reconcile.Result{RequeueAfter: clusterRetryInterval}, nil
+	}
+	// The recovered cluster is reported right away, the reconcile may stop before the next status write
+	recovered := rdbc.Status.IsConditionTrue(rdbcv1beta1.RdbcConditionClusterUnavailable)
+	rdbc.Status.SetCondition(rdbcv1beta1.RdbcConditionClusterUnavailable, corev1.ConditionFalse, ReasonClusterAvailable, "")
+	if recovered {
+		if err := r.updateRdbcStatus(rdbc); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 
 	// Init finalizers
-	isRdbcMarkedToBeDeleted, err := r.initFinalization(rdbc, redis)
-	if err != nil {
+	if result, err := r.initFinalization(rdbc, redis, cluster); err != nil {
 		reqLogger.Error(err, "Failed to initialize finalizer")
-		if err := r.updateRdbcStatus(fmt.Sprintf("%v", err), rdbc); err != nil {
+		if err := r.setRdbcError(rdbc, ReasonFinalizerFailed, err); err != nil {
 			reqLogger.Error(err, "Failed to update CR status")
 		}
 		return reconcile.Result{}, err
+	} else if result != nil {
+		// The Rdbc is marked to be deleted
+		return *result, nil
 	}
-	if isRdbcMarkedToBeDeleted {
+
+	// Move the plaintext password of the v1alpha1 Rdbc to a Secret
+	if _, err := r.migratePassword(rdbc); err != nil {
+		reqLogger.Error(err, "Failed to migrate the v1alpha1 password")
+		if err := r.setRdbcError(rdbc, ReasonPasswordFailed, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Validate the spec before making any changes to the db
+	if errs := rdbcv1beta1.ValidateRdbcSpec(desiredRdbcSpec(rdbc), field.NewPath("spec")); len(errs) > 0 {
+		err := errs.ToAggregate()
+		reqLogger.Error(err, "Invalid Rdbc spec")
+		if err := r.setRdbcError(rdbc, ReasonInvalidSpec, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+			return reconcile.Result{}, err
+		}
+		// The spec must be fixed by the user, the CR update triggers a new reconcile
+		return reconcile.Result{}, nil
+	}
+
+	// The password is read from the Secret on every reconcile, thus the Secret changes are applied to the db
+	password, err := r.resolvePassword(rdbc)
+	if err != nil {
+		reqLogger.Error(err, "Failed to get db password")
+		if err := r.setRdbcError(rdbc, ReasonPasswordFailed, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Adopt the existing db instead of creating a new one
+	if result, err := r.adoptDb(rdbc, password, redis, cluster); err != nil {
+		reqLogger.Error(err, "Failed to adopt db")
+		if err := r.setRdbcError(rdbc, ReasonAdoptionFailed, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+		}
 		return reconcile.Result{}, err
+	} else if result != nil {
+		return *result, nil
 	}
 
 	// Init redis db
-	redisDb, err := r.initRedisDb(rdbc, redis)
+	redisDb, err := r.initRedisDb(rdbc, password, redis)
 	if err != nil {
 		reqLogger.Error(err, "Failed to init RedisDB")
+		if err := r.setRdbcError(rdbc, ReasonRedisApiError, err); err != nil {
+			reqLogger.Error(err, "Failed to update CR status")
+		}
 		return reconcile.Result{}, err
 	}
 
-	dbExists, err := redis.CheckIfDbExists(redisDb.Uid)
+	dbExists, err := CheckIfDbExists(redisDb.Uid, redis)
 	if err != nil {
 		reqLogger.Error(err, "Failed to check if db already exists")
-		if err := r.updateRdbcStatus(fmt.Sprintf("%v", err), rdbc); err != nil {
+		if err := r.setRdbcError(rdbc, ReasonRedisApiError, err); err != nil {
 			reqLogger.Error(err, "Failed to update CR status")
 		}
 		return reconcile.Result{}, err
 	}
 	if dbExists {
-		if err := r.syncCR(rdbc, redisDb, redis); err != nil {
+		// Wait for the db to become active before applying any changes
+		if result, err := r.waitForDbActive(rdbc, redisDb, redis); err != nil {
+			reqLogger.Error(err, "Failed to check db provisioning")
+			if err := r.setRdbcError(rdbc, ReasonRedisApiError, err); err != nil {
+				reqLogger.Error(err, "Failed to update CR status")
+			}
+			return reconcile.Result{}, err
+		} else if result != nil {
+			return *result, nil
+		}
+		// Rotate the password if due, the rotation isn't held by a running restore or by the quotas
+		if rotated, err := r.rotatePassword(rdbc, redisDb, redis); err != nil {
+			reqLogger.Error(err, "Failed to rotate db password")
+			if err := r.setRdbcError(rdbc, ReasonRotationFailed, err); err != nil {
+				reqLogger.Error(err, "Failed to update CR status")
+			}
+			return reconcile.Result{}, err
+		} else if rotated != "" {
+			password = rotated
+		}
+		// The spec changes must not race with a running import, they are applied once the restore finishes
+		if rdbc.Generation != rdbc.Status.ObservedGeneration {
+			restore, err := r.runningRestore(rdbc)
+			if err != nil {
+				reqLogger.Error(err, "Failed to list RdbcRestores")
+				return reconcile.Result{}, err
+			}
+			if restore != "" {
+				reqLogger.Info(fmt.Sprintf("spec changes wait for RdbcRestore %s to finish", restore))
+				return reconcile.Result{}, r.setRdbcWaitingForRestore(rdbc, restore)
+			}
+		}
+		// The spec changes which grow the db must fit the namespace quotas
+		if err := r.checkQuota(rdbc, redisDb); err != nil {
+			if _, ok := err.(*quotaExceededError); ok {
+				reqLogger.Info(fmt.Sprintf("spec changes exceed the quota: %v", err))
+				if err := r.setRdbcQuotaExceeded(rdbc, err); err != nil {
+					reqLogger.Error(err, "Failed to update CR status")
+					return reconcile.Result{}, err
+				}
+				// Retried once the quota is raised, or periodically while the other dbs of the namespace may shrink
+				return reconcile.Result{RequeueAfter: quotaRetryInterval}, nil
+			}
+			reqLogger.Error(err, "Failed to check the quota")
+			return reconcile.Result{}, err
+		}
+		// Apply spec changes to the existing db
+		rejected, drift, immutable, err := r.applyRdbcSpec(rdbc, redisDb, password, redis)
+		if err != nil {
+			reqLogger.Error(err, "unable to update db")
+			if err := r.setRdbcError(rdbc, ReasonUpdateFailed, err); err != nil {
+				reqLogger.Error(err, "Failed to update CR status")
+			}
+			return reconcile.Result{}, err
+		}
+		// The db can't be moved to another cluster
+		if rdbc.Spec.ClusterRef != "" && rdbc.Spec.ClusterRef != clusterName(cluster) {
+			rejected = append(rejected, fmt.Sprintf("clusterRef can't be changed, the db was created on cluster: %q", clusterName(cluster)))
+		}
+		if err := r.syncCR(rdbc, redisDb, redis, cluster); err != nil {
+			return reconcile.Result{}, err
+		}
+		setDriftCondition(&rdbc.Status, desiredRdbcSpec(rdbc).DriftPolicy, drift, immutable)
+		r.updateUsage(rdbc, redisDb, redis)
+		// The changes which can't be made in place are reported in status,
+		// the spec is kept as is, the db is left untouched
+		if err := r.setRdbcReady(rdbc, redisDb, rejected, observed); err != nil {
 			return reconcile.Result{}, err
 		}
 	} else {
-		if err := redis.CreateDb(redisDb); err != nil {
+		// Check the requested modules against the modules installed on the cluster
+		moduleList, err := resolveModules(desiredRdbcSpec(rdbc).Modules, redis)
+		if _, ok := err.(*unknownModulesError); ok {
+			reqLogger.Error(err, "unknown db modules")
+			if err := r.setRdbcError(rdbc, ReasonUnknownModule, err); err != nil {
+				reqLogger.Error(err, "Failed to update CR status")
+				return reconcile.Result{}, err
+			}
+			// The spec must be fixed by the user, the CR update triggers a new reconcile
+			return reconcile.Result{}, nil
+		} else if err != nil {
+			reqLogger.Error(err, "unable to resolve db modules")
+			if err := r.setRdbcError(rdbc, ReasonRedisApiError, err); err != nil {
+				reqLogger.Error(err, "Failed to update CR status")
+			}
+			return reconcile.Result{}, err
+		}
+		redisDb.ModuleList = moduleList
+		// The new db must fit the namespace quotas
+		if err := r.checkQuota(rdbc, nil); err != nil {
+			if _, ok := err.(*quotaExceededError); ok {
+				reqLogger.Info(fmt.Sprintf("db exceeds the quota: %v", err))
+				if err := r.setRdbcQuotaExceeded(rdbc, err); err != nil {
+					reqLogger.Error(err, "Failed to update CR status")
+					return reconcile.Result{}, err
+				}
+				// Retried once the quota is raised, or periodically while the other dbs of the namespace may shrink
+				return reconcile.Result{RequeueAfter: quotaRetryInterval}, nil
+			}
+			reqLogger.Error(err, "Failed to check the quota")
+			return reconcile.Result{}, err
+		}
+		createdDb, err := redis.CreateBdb(redisDb)
+		if err != nil {
 			reqLogger.Error(err, "unable create new db")
-			if err := r.updateRdbcStatus(fmt.Sprintf("%v", err), rdbc); err != nil {
+			metrics.IncProvisioningFailures(rdbc.Namespace, ReasonCreateFailed)
+			if err := r.setRdbcError(rdbc, ReasonCreateFailed, err); err != nil {
 				reqLogger.Error(err, "Failed to update CR status")
 			}
 			return reconcile.Result{}, err
 		}
-		err := r.syncCR(rdbc, redisDb, redis)
+		err = r.syncCR(rdbc, redisDb, redis, cluster)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		// The db is created asynchronously, track the creation action
+		// until the db is active, the Secret is created once the db is ready
+		if err := r.setRdbcProvisioning(rdbc, createdDb.ActionUid, "db creation started"); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: provisioningMinInterval}, nil
 	}
 
 	reconcileResult, err := r.manageSecret(rdbc, redisDb)
 	if err != nil {
-		message := fmt.Sprintf("%v", err)
-		if err := r.updateRdbcStatus(message, rdbc); err != nil {
+		if err := r.setRdbcError(rdbc, ReasonSecretFailed, err); err != nil {
 			reqLogger.Error(err, "Failed to update CR status")
 		}
 		return reconcile.Result{}, err
@@ -143,40 +456,263 @@ func (r *ReconcileRdbc) Reconcile(request reconcile.Request) (reconcile.Result,
 		return *reconcileResult, nil
 	}
 
-	return reconcile.Result{}, nil
+	// Requeue for the next password rotation, if enabled, for the next resync or for the next usage read
+	return reconcile.Result{RequeueAfter: r.requeueAfter(rdbc)}, nil
 }
 
-func (r *ReconcileRdbc) syncCR(rdbc *rdbcv1alpha1.Rdbc, redisDb *RedisDb, redis *RedisConfig) error {
+func (r *ReconcileRdbc) syncCR(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, redis redisenterprise.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster) error {
 	newDb := false
-	if _, ok := rdbc.ObjectMeta.Annotations["dbuid"]; !ok {
+	if _, ok := rdbc.ObjectMeta.Annotations[dbUidAnnotation]; !ok {
 		newDb = true
 	}
-	rdbc.ObjectMeta.Annotations = map[string]string{"dbuid": fmt.Sprint(redisDb.Uid)}
-	rdbc.Spec.Name = redisDb.Name
-	rdbc.Spec.Size = redisDb.MemorySize
-	// Once the spec is updated with valid redis db parameters update the CR in K8S
+	rdbc.Status.Cluster = clusterName(cluster)
+	annotated := rdbc.ObjectMeta.Annotations[dbUidAnnotation] == fmt.Sprint(redisDb.Uid) &&
+		(cluster == nil || rdbc.ObjectMeta.Annotations[clusterAnnotation] == cluster.Name)
+	if annotated {
+		return nil
+	}
+	setDbAnnotations(rdbc, redisDb.Uid, cluster)
+	// Once the CR is annotated with the dbuid update the CR in K8S
 	// If for some reason, the update is failed, make sure that it's not a new db request
 	// if it's new db request, remove the created db
-	if err := r.client.Update(context.TODO(), rdbc); err != nil {
+	if err := r.updateRdbc(rdbc); err != nil {
 		log.Error(err, "failed to update RDBC CR", "Name", rdbc.Name)
 		if newDb {
 			log.Info(fmt.Sprintf("unable to update RDBC CR afeter new DB created, gonna remove new DB, dbid: %d", redisDb.Uid))
 			// If wasn't able to delete new created db, we are fucked up!
-			if err := redis.DeleteDb(redisDb.Uid); err != nil {
+			if err := DeleteDb(redisDb.Uid, redis); err != nil {
 				log.Error(err, "Houston, we have a problem! Kill me now, or I'll destroy you Redis cluster, madafaka!")
 				return err
 			}
 		}
 		return err
 	}
-	if err := r.updateRdbcStatus(fmt.Sprintf("%v", "db is ready"), rdbc); err != nil {
-		log.Error(err, "Failed to update CR status")
+	return nil
+}
+
+// updateRdbc writes the metadata and the spec of the Rdbc, the update returns the stored status,
+// thus the status changes made by the reconcile are restored to be written with the status
+func (r *ReconcileRdbc) updateRdbc(rdbc *rdbcv1beta1.Rdbc) error {
+	status := rdbc.Status.DeepCopy()
+	if err := r.client.Update(context.TODO(), rdbc); err != nil {
 		return err
 	}
+	rdbc.Status = *status
 	return nil
 }
 
-func (r *ReconcileRdbc) initRedisDb(rdbc *rdbcv1alpha1.Rdbc, redis *RedisConfig) (*RedisDb, error) {
+// setDbAnnotations annotates the Rdbc with the db uid and the cluster, the other annotations are kept
+func setDbAnnotations(rdbc *rdbcv1beta1.Rdbc, dbUid int32, cluster *rdbcv1alpha1.RedisEnterpriseCluster) {
+	if rdbc.ObjectMeta.Annotations == nil {
+		rdbc.ObjectMeta.Annotations = map[string]string{}
+	}
+	rdbc.ObjectMeta.Annotations[dbUidAnnotation] = fmt.Sprint(dbUid)
+	// The db is bound to the cluster it was created on
+	if cluster != nil {
+		rdbc.ObjectMeta.Annotations[clusterAnnotation] = cluster.Name
+	}
+}
+
+// waitForDbActive checks the pending action and the db status,
+// returns nil result once the db is active and has endpoints,
+// otherwise the result to requeue with while the db is provisioning
+func (r *ReconcileRdbc) waitForDbActive(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, redis redisenterprise.Client) (*reconcile.Result, error) {
+	actionUid := rdbc.Status.PendingAction
+	message := fmt.Sprintf("db status: %s", redisDb.Status)
+	if actionUid != "" {
+		action, err := redis.GetAction(actionUid)
+		// Completed actions are purged by the cluster after a while,
+		// in such case rely on the db status only
+		if err != nil && !redisenterprise.IsNotFound(err) {
+			return nil, err
+		}
+		if action != nil {
+			if action.Status == redisenterprise.ActionStatusFailed || action.Status == redisenterprise.ActionStatusCancelled {
+				err := fmt.Errorf("db creation action %s %s: %s", actionUid, action.Status, action.Error)
+				log.Error(err, fmt.Sprintf("failed to provision dbid: %d", redisDb.Uid))
+				countProvisionFailure(rdbc)
+				rdbc.Status.SetCondition(rdbcv1beta1.RdbcConditionProvisioning, corev1.ConditionFalse, ReasonProvisionFailed, err.Error())
+				return &reconcile.Result{}, r.setRdbcError(rdbc, ReasonProvisionFailed, err)
+			}
+			message = fmt.Sprintf("db status: %s, action %s %s, progress: %v%%", redisDb.Status, action.Name, action.Status, action.Progress)
+		}
+	}
+	if redisDb.Status == redisenterprise.BdbStatusCreationFailed {
+		err := fmt.Errorf("db creation failed, dbid: %d", redisDb.Uid)
+		countProvisionFailure(rdbc)
+		rdbc.Status.SetCondition(rdbcv1beta1.RdbcConditionProvisioning, corev1.ConditionFalse, ReasonProvisionFailed, err.Error())
+		return &reconcile.Result{}, r.setRdbcError(rdbc, ReasonProvisionFailed, err)
+	}
+	if redisDb.Status == redisenterprise.BdbStatusActive && len(redisDb.Endpoints) > 0 {
+		rdbc.Status.PendingAction = ""
+		return nil, nil
+	}
+	log.Info(fmt.Sprintf("dbid: %d is not active yet, %s", redisDb.Uid, message))
+	if err := r.setRdbcProvisioning(rdbc, actionUid, message); err != nil {
+		return nil, err
+	}
+	return &reconcile.Result{RequeueAfter: provisioningRequeueAfter(rdbc)}, nil
+}
+
+// countProvisionFailure counts the failed db provisioning once, the failed Rdbc is reconciled again
+func countProvisionFailure(rdbc *rdbcv1beta1.Rdbc) {
+	if c := rdbc.Status.GetCondition(rdbcv1beta1.RdbcConditionProvisioning); c == nil || c.Reason != ReasonProvisionFailed {
+		metrics.IncProvisioningFailures(rdbc.Namespace, ReasonProvisionFailed)
+	}
+}
+
+// provisioningRequeueAfter backs off the polling interval
+// as the time since the provisioning started grows
+func provisioningRequeueAfter(rdbc *rdbcv1beta1.Rdbc) time.Duration {
+	c := rdbc.Status.GetCondition(rdbcv1beta1.RdbcConditionProvisioning)
+	if c == nil {
+		return provisioningMinInterval
+	}
+	interval := time.Since(c.LastTransitionTime.Time) / 2
+	if interval < provisioningMinInterval {
+		return provisioningMinInterval
+	}
+	if interval > provisioningMaxInterval {
+		return provisioningMaxInterval
+	}
+	return interval
+}
+
+// applyRdbcSpec compares the desired spec with the db loaded from the cluster
+// and sends the differences as an in place update.
+// Once the spec generation was applied, the differences are the out of band changes of the db,
+// they are applied back or only reported by the drift policy, the password changes are always applied.
+// With the Report policy a new generation applies only the spec fields changed since the last applied spec,
+// the other differences are reported as drift.
+// Returns the list of changes which can't be applied in place, the list of the out of band changes
+// and the list of the out of band changes of the fields which can't be changed in place.
+func (r *ReconcileRdbc) applyRdbcSpec(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, password string, redis redisenterprise.Client) ([]string, []string, []string, error) {
+	spec := desiredRdbcSpec(rdbc)
+	last := appliedSpec(rdbc)
+	update, rejected := diffRdbcSpec(spec, password, redisDb)
+	var drift, immutable []string
+	if rdbc.Generation == rdbc.Status.ObservedGeneration {
+		drift, immutable = describeDrift(redisDb, update, last)
+		if spec.DriftPolicy == rdbcv1beta1.DriftPolicyReport {
+			update = passwordUpdate(update)
+		}
+	} else if spec.DriftPolicy == rdbcv1beta1.DriftPolicyReport && last != nil {
+		var driftUpdate *redisenterprise.Bdb
+		update, driftUpdate = splitSpecChanges(update, last, spec)
+		drift, immutable = describeDrift(redisDb, driftUpdate, last)
+	}
+	if len(drift) > 0 || len(immutable) > 0 {
+		log.Info(fmt.Sprintf("dbid: %d drifted from the spec, drift policy: %s, drift: %v", redisDb.Uid, spec.DriftPolicy, append(drift, immutable...)))
+	}
+	if update != nil {
+		log.Info(fmt.Sprintf("applying spec changes to dbid: %d", redisDb.Uid))
+		if _, err := redis.UpdateBdb(redisDb.Uid, update); err != nil {
+			log.Error(err, fmt.Sprintf("failed to update dbid: %d", redisDb.Uid))
+			return rejected, drift, immutable, err
+		}
+		// Keep the loaded db in sync with the applied changes
+		if err := mergeBdb(redisDb, update); err != nil {
+			return rejected, drift, immutable, err
+		}
+	}
+	if changed, err := recordAppliedSpec(rdbc, spec, last, redisDb); err != nil {
+		return rejected, drift, immutable, err
+	} else if changed {
+		if err := r.updateRdbc(rdbc); err != nil {
+			log.Error(err, "failed to record the applied spec", "Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+			return rejected, drift, immutable, err
+		}
+	}
+	return rejected, drift, immutable, nil
+}
+
+// desiredRdbcSpec returns the spec with the defaults of the unset fields,
+// the defaults are persisted in the CR by the defaulting webhook, if it's deployed
+func desiredRdbcSpec(rdbc *rdbcv1beta1.Rdbc) *rdbcv1beta1.RdbcSpec {
+	spec := rdbc.Spec.DeepCopy()
+	rdbcv1beta1.SetDefaults_RdbcSpec(spec)
+	return spec
+}
+
+// diffRdbcSpec returns the in place update required to bring the db to the desired spec,
+// or nil if the db is up to date, and the list of changes which can't be made in place
+func diffRdbcSpec(spec *rdbcv1beta1.RdbcSpec, password string, redisDb *redisenterprise.Bdb) (*redisenterprise.Bdb, []string) {
+	var rejected []string
+	update := &redisenterprise.Bdb{}
+	changed := false
+	// The DB name is used by the apps and by the Redis team to identify the DB,
+	// renaming the DB requires recreation
+	if spec.Name != redisDb.Name {
+		rejected = append(rejected, fmt.Sprintf("name can't be changed from %s to %s", redisDb.Name, spec.Name))
+	}
+	// User set DB size in Megabytes, API uses memory size in bytes
+	if redisenterprise.MegabytesToBytes(spec.Size) != redisDb.MemorySize {
+		update.MemorySize = redisenterprise.MegabytesToBytes(spec.Size)
+		changed = true
+	}
+	// Password is optional, if it's not set by user keep the current one
+	if password != "" && password != redisDb.Password {
+		update.Password = password
+		changed = true
+	}
+	if spec.Replication != redisenterprise.BoolValue(redisDb.Replication) {
+		update.Replication = redisenterprise.Bool(spec.Replication)
+		changed = true
+	}
+	// Re-sharding may only increase the shards count,
+	// the hashing policy of a sharded db can't be changed
+	currentShards := redisDb.ShardsCount
+	if currentShards == 0 {
+		currentShards = rdbcv1beta1.DefaultShardsCount
+	}
+	if spec.ShardsCount < currentShards {
+		rejected = append(rejected, fmt.Sprintf("shardsCount can't be decreased from %d to %d", currentShards, spec.ShardsCount))
+	} else if spec.ShardsCount > currentShards {
+		update.ShardsCount = spec.ShardsCount
+		if currentShards == 1 {
+			update.Sharding = redisenterprise.Bool(true)
+			update.ShardKeyRegex = shardKeyRegex(spec.ShardKeyRegex)
+		}
+		changed = true
+	}
+	if currentShards > 1 && spec.ShardsCount > 1 && !equalShardKeyRegex(spec.ShardKeyRegex, redisDb.ShardKeyRegex) {
+		rejected = append(rejected, "shardKeyRegex of a sharded db can't be changed")
+	}
+	if spec.OSSCluster != redisenterprise.BoolValue(redisDb.OSSCluster) {
+		update.OSSCluster = redisenterprise.Bool(spec.OSSCluster)
+		changed = true
+	}
+	if spec.ProxyPolicy != redisDb.ProxyPolicy {
+		update.ProxyPolicy = spec.ProxyPolicy
+		changed = true
+	}
+	if spec.DataPersistence != redisDb.DataPersistence {
+		update.DataPersistence = spec.DataPersistence
+		changed = true
+	}
+	if spec.DataPersistence == rdbcv1beta1.DataPersistenceAof && spec.AofPolicy != redisDb.AofPolicy {
+		update.AofPolicy = spec.AofPolicy
+		changed = true
+	}
+	if spec.DataPersistence == rdbcv1beta1.DataPersistenceSnapshot && !equalSnapshotPolicy(spec.SnapshotPolicy, redisDb.SnapshotPolicy) {
+		update.SnapshotPolicy = snapshotPolicy(spec.SnapshotPolicy)
+		changed = true
+	}
+	// Modules are loaded on the db creation only
+	if !equalModules(spec.Modules, redisDb.ModuleList) {
+		rejected = append(rejected, "modules can't be changed after the db creation")
+	}
+	if spec.EvictionPolicy != redisDb.EvictionPolicy {
+		update.EvictionPolicy = spec.EvictionPolicy
+		changed = true
+	}
+	if !changed {
+		return nil, rejected
+	}
+	return update, rejected
+}
+
+func (r *ReconcileRdbc) initRedisDb(rdbc *rdbcv1beta1.Rdbc, password string, redis redisenterprise.Client) (*redisenterprise.Bdb, error) {
 
 	// Try fetch dbuid from CR annotation
 	dbUid, err := getDbUid(rdbc)
@@ -186,10 +722,16 @@ func (r *ReconcileRdbc) initRedisDb(rdbc *rdbcv1alpha1.Rdbc, redis *RedisConfig)
 	}
 	// If dbuid is set, load redis db
 	if dbUid != nil {
-		return redis.LoadRedisDb(*dbUid)
+		return LoadRedisDb(*dbUid, redis)
 	} else {
-		// It's a new DB
-		db, err := NewRedisDb(rdbc.Spec.Name, rdbc.Spec.Size, rdbc.Spec.Password, redis)
+		// It's a new DB, generate the password if it's not set by user
+		if password == "" {
+			password, err = r.generatePassword(rdbc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		db, err := NewRedisDb(desiredRdbcSpec(rdbc), password, redis)
 		if err != nil {
 			return nil, err
 		}
@@ -197,34 +739,55 @@ func (r *ReconcileRdbc) initRedisDb(rdbc *rdbcv1alpha1.Rdbc, redis *RedisConfig)
 	}
 }
 
-func (r *ReconcileRdbc) initFinalization(rdbc *rdbcv1alpha1.Rdbc, redis *RedisConfig) (bool, error) {
+// initFinalization adds the finalizer or runs it if the Rdbc is marked to be deleted,
+// returns the result to stop the reconcile with if the Rdbc is marked to be deleted
+func (r *ReconcileRdbc) initFinalization(rdbc *rdbcv1beta1.Rdbc, redis redisenterprise.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster) (*reconcile.Result, error) {
+	policy := r.deletionPolicy(rdbc)
 	isRdbcMarkedToBeDeleted := rdbc.GetDeletionTimestamp() != nil
 	if isRdbcMarkedToBeDeleted {
 		if contains(rdbc.GetFinalizers(), rdbcFinalizer) {
-			if err := r.finalizeRdbc(rdbc, redis); err != nil {
+			if err := r.setRdbcDeleting(rdbc); err != nil {
+				return nil, err
+			}
+			done, err := r.finalizeRdbc(rdbc, redis, cluster, policy)
+			if err != nil {
 				log.Error(err, "Failed to run finalizer")
-				return isRdbcMarkedToBeDeleted, err
+				return nil, err
+			}
+			// Wait for the db export
+			if !done {
+				return &reconcile.Result{RequeueAfter: exportPollInterval}, nil
 			}
 			rdbc.SetFinalizers(remove(rdbc.GetFinalizers(), rdbcFinalizer))
-			err := r.client.Update(context.TODO(), rdbc)
+			err = r.updateRdbc(rdbc)
 			if err != nil {
 				log.Error(err, "wasn't able to update CR")
-				return isRdbcMarkedToBeDeleted, err
+				return nil, err
 			}
 		}
-		return isRdbcMarkedToBeDeleted, nil
+		return &reconcile.Result{}, nil
 	}
 
+	// The retained db needs no cleanup, thus the Rdbc is deleted without waiting for the operator
+	if policy == rdbcv1beta1.DeletionPolicyRetain {
+		if contains(rdbc.GetFinalizers(), rdbcFinalizer) {
+			if err := r.removeFinalizer(rdbc); err != nil {
+				log.Error(err, "Failed to remove finalizer")
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
 	if !contains(rdbc.GetFinalizers(), rdbcFinalizer) {
 		if err := r.addFinalizer(rdbc); err != nil {
 			log.Error(err, "Failed to add finalizer")
-			return isRdbcMarkedToBeDeleted, err
+			return nil, err
 		}
 	}
-	return isRdbcMarkedToBeDeleted, nil
+	return nil, nil
 }
 
-func (r *ReconcileRdbc) manageSecret(rdbc *rdbcv1alpha1.Rdbc, redisDb *RedisDb) (*reconcile.Result, error) {
+func (r *ReconcileRdbc) manageSecret(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb) (*reconcile.Result, error) {
 	secret := &corev1.Secret{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: rdbc.Name, Namespace: rdbc.Namespace}, secret)
 	if err != nil && errors.IsNotFound(err) {
@@ -258,13 +821,13 @@ func (r *ReconcileRdbc) manageSecret(rdbc *rdbcv1alpha1.Rdbc, redisDb *RedisDb)
 	return nil, nil
 }
 
-func (r *ReconcileRdbc) secretForRdbc(rdbc *rdbcv1alpha1.Rdbc, redisDb *RedisDb, secret *corev1.Secret) error {
+func (r *ReconcileRdbc) secretForRdbc(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, secret *corev1.Secret) error {
 	labels := map[string]string{
 		"app":   rdbc.Name,
 		"dbuid": fmt.Sprint(redisDb.Uid),
 	}
 	stringData := map[string]string{
-		"endpoint": redisDb.endpoint,
+		"endpoint": dbEndpoint(redisDb),
 		"password": redisDb.Password,
 	}
 
@@ -279,29 +842,52 @@ func (r *ReconcileRdbc) secretForRdbc(rdbc *rdbcv1alpha1.Rdbc, redisDb *RedisDb,
 	return nil
 }
 
-func (r *ReconcileRdbc) finalizeRdbc(rdbc *rdbcv1alpha1.Rdbc, redis *RedisConfig) error {
+// finalizeRdbc deletes the db, the Snapshot deletion policy exports the db first, the Retain policy keeps it.
+// Returns false while the export is running.
+func (r *ReconcileRdbc) finalizeRdbc(rdbc *rdbcv1beta1.Rdbc, redis redisenterprise.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster, policy string) (bool, error) {
 
 	// Try fetch dbuid from CR annotation
 	dbId, err := getDbUid(rdbc)
 	if err != nil {
 		log.Error(err, fmt.Sprintf("wasn't able to convert from string to int for CR: %s", rdbc.Spec.Name))
-		return err
+		return false, err
+	}
+	// The db was never created or adopted
+	if dbId == nil {
+		return true, nil
+	}
+	// The policy was changed to Retain after the finalizer was added, the db is kept
+	if policy == rdbcv1beta1.DeletionPolicyRetain {
+		log.Info(fmt.Sprintf("dbid: %d is retained", *dbId))
+		return true, nil
+	}
+	if policy == rdbcv1beta1.DeletionPolicySnapshot {
+		exported, err := r.exportBeforeDelete(rdbc, *dbId, redis, cluster)
+		if err != nil || !exported {
+			return false, err
+		}
 	}
 
-	err = redis.DeleteDb(*dbId)
+	err = DeleteDb(*dbId, redis)
 	if err != nil {
 		log.Error(err, "Failed to delete db at finalizer")
-		return err
+		return false, err
 	}
-	log.Info(fmt.Sprintf("Successfully finalized Rdbc: %d", dbId))
-	return nil
+	log.Info(fmt.Sprintf("Successfully finalized Rdbc: %d", *dbId))
+	return true, nil
 }
 
-func (r *ReconcileRdbc) addFinalizer(rdbc *rdbcv1alpha1.Rdbc) error {
+func (r *ReconcileRdbc) removeFinalizer(rdbc *rdbcv1beta1.Rdbc) error {
+	log.Info("Removing Finalizer of the Rdbc, the db is retained")
+	rdbc.SetFinalizers(remove(rdbc.GetFinalizers(), rdbcFinalizer))
+	return r.updateRdbc(rdbc)
+}
+
+func (r *ReconcileRdbc) addFinalizer(rdbc *rdbcv1beta1.Rdbc) error {
 	log.Info("Adding Finalizer for the Rdbc")
 	rdbc.SetFinalizers(append(rdbc.GetFinalizers(), rdbcFinalizer))
 	// Update CR
-	err := r.client.Update(context.TODO(), rdbc)
+	err := r.updateRdbc(rdbc)
 	if err != nil {
 		log.Error(err, "Failed to update Rdbc with finalizer")
 		return err
@@ -309,15 +895,29 @@ func (r *ReconcileRdbc) addFinalizer(rdbc *rdbcv1alpha1.Rdbc) error {
 	return nil
 }
 
-func (r *ReconcileRdbc) updateRdbcStatus(message string, rdbc *rdbcv1alpha1.Rdbc) error {
-	rdbc.Status.Message = message
-	// https://github.com/operator-framework/operator-sdk/issues/981
-	//if err := r.client.Status().Update(context.TODO(), rdbc); err != nil {
-	if err := r.client.Update(context.TODO(), rdbc); err != nil {
-		log.Error(err, "Failed to update CR status")
-		return err
+// rdbcChanged returns true if the spec or the metadata read by the reconcile changed,
+// the annotations carry the db uid, the cluster and the v1alpha1 password
+func rdbcChanged(old metav1.Object, new metav1.Object) bool {
+	return old.GetGeneration() != new.GetGeneration() ||
+		!reflect.DeepEqual(old.GetAnnotations(), new.GetAnnotations()) ||
+		!reflect.DeepEqual(old.GetFinalizers(), new.GetFinalizers()) ||
+		(old.GetDeletionTimestamp() == nil) != (new.GetDeletionTimestamp() == nil)
+}
+
+// rdbcsWithUnavailableCluster returns the requests of the Rdbcs which failed to use their cluster
+func rdbcsWithUnavailableCluster(c client.Client) []reconcile.Request {
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := c.List(context.TODO(), &client.ListOptions{}, rdbcs); err != nil {
+		log.Error(err, "Failed to list Rdbcs")
+		return nil
 	}
-	return nil
+	var requests []reconcile.Request
+	for _, rdbc := range rdbcs.Items {
+		if rdbc.Status.IsConditionTrue(rdbcv1beta1.RdbcConditionClusterUnavailable) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rdbc.Name, Namespace: rdbc.Namespace}})
+		}
+	}
+	return requests
 }
 
 func contains(list []string, s string) bool {
@@ -338,8 +938,8 @@ func remove(list []string, s string) []string {
 	return list
 }
 
-func getDbUid(rdbc *rdbcv1alpha1.Rdbc) (*int32, error) {
-	if dbidValue, ok := rdbc.ObjectMeta.Annotations["dbuid"]; ok {
+func getDbUid(rdbc *rdbcv1beta1.Rdbc) (*int32, error) {
+	if dbidValue, ok := rdbc.ObjectMeta.Annotations[dbUidAnnotation]; ok {
 		// Existing DB, fetch db details and sync into cluster
 		dbid, err := strconv.Atoi(dbidValue)
 		if err != nil {
@@ -352,9 +952,9 @@ func getDbUid(rdbc *rdbcv1alpha1.Rdbc) (*int32, error) {
 	return nil, nil
 }
 
-func (r *ReconcileRdbc) removeFinalizerAndUpdateCR(rdbc *rdbcv1alpha1.Rdbc) error {
+func (r *ReconcileRdbc) removeFinalizerAndUpdateCR(rdbc *rdbcv1beta1.Rdbc) error {
 	rdbc.SetFinalizers(remove(rdbc.GetFinalizers(), rdbcFinalizer))
-	err := r.client.Update(context.TODO(), rdbc)
+	err := r.updateRdbc(rdbc)
 	if err != nil {
 		log.Error(err, "Failed to delete finalizer")
 		return err
@@ -0,0 +1,574 @@
+package rdbc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rdbc-operator/pkg/apis"
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/passwordgen"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	"github.com/rdbc-operator/pkg/redisenterprise/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testNamespace = "default"
+	testName      = "cache"
+	testCluster   = "main"
+	// testTimeout is the Redis API client timeout, the Latency faults above it time the requests out
+	testTimeout = 500 * time.Millisecond
+)
+
+// failingUpdateClient fails the Rdbc metadata and spec updates, the status updates pass through
+type failingUpdateClient struct {
+	client.Client
+}
+
+func (c *failingUpdateClient) Update(ctx context.Context, obj runtime.Object) error {
+	if _, ok := obj.(*rdbcv1beta1.Rdbc); ok {
+		return fmt.Errorf("injected Rdbc update failure")
+	}
+	return c.Client.Update(ctx, obj)
+}
+
+func newTestRdbc(modify func(rdbc *rdbcv1beta1.Rdbc)) *rdbcv1beta1.Rdbc {
+	rdbc := &rdbcv1beta1.Rdbc{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace, Generation: 1},
+		Spec:       rdbcv1beta1.RdbcSpec{Name: testName, Size: 100},
+	}
+	if modify != nil {
+		modify(rdbc)
+	}
+	return rdbc
+}
+
+// managed returns the Rdbc of the db created on the test cluster, with the finalizer
+func managed(dbUid int32, modify func(rdbc *rdbcv1beta1.Rdbc)) *rdbcv1beta1.Rdbc {
+	return newTestRdbc(func(rdbc *rdbcv1beta1.Rdbc) {
+		rdbc.Annotations = map[string]string{dbUidAnnotation: fmt.Sprint(dbUid), clusterAnnotation: testCluster}
+		rdbc.Finalizers = []string{rdbcFinalizer}
+		if modify != nil {
+			modify(rdbc)
+		}
+	})
+}
+
+// activeBdb returns a db matching the defaulted spec of newTestRdbc
+func activeBdb(uid int32, modify func(bdb *redisenterprise.Bdb)) redisenterprise.Bdb {
+	bdb := redisenterprise.Bdb{
+		Uid:             uid,
+		Name:            testName,
+		Type:            "redis",
+		MemorySize:      redisenterprise.MegabytesToBytes(100),
+		Status:          redisenterprise.BdbStatusActive,
+		ShardsCount:     1,
+		ProxyPolicy:     rdbcv1beta1.ProxyPolicySingle,
+		DataPersistence: rdbcv1beta1.DataPersistenceDisabled,
+		EvictionPolicy:  rdbcv1beta1.EvictionPolicyVolatileLru,
+	}
+	if modify != nil {
+		modify(&bdb)
+	}
+	return bdb
+}
+
+// setAppliedSpec records the defaulted spec of the Rdbc as applied, modified by modify
+func setAppliedSpec(rdbc *rdbcv1beta1.Rdbc, modify func(spec *rdbcv1beta1.RdbcSpec)) {
+	spec := desiredRdbcSpec(rdbc)
+	if modify != nil {
+		modify(spec)
+	}
+	value, _ := json.Marshal(spec)
+	rdbc.Annotations[appliedSpecAnnotation] = string(value)
+}
+
+func getRdbc(t *testing.T, c client.Client) *rdbcv1beta1.Rdbc {
+	rdbc := &rdbcv1beta1.Rdbc{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: testName, Namespace: testNamespace}, rdbc); err != nil {
+		t.Fatalf("failed to get Rdbc: %v", err)
+	}
+	return rdbc
+}
+
+func wantCondition(t *testing.T, rdbc *rdbcv1beta1.Rdbc, conditionType rdbcv1beta1.RdbcConditionType, status corev1.ConditionStatus, reason string) {
+	c := rdbc.Status.GetCondition(conditionType)
+	if c == nil {
+		t.Fatalf("condition %s is not set, conditions: %+v", conditionType, rdbc.Status.Conditions)
+	}
+	if c.Status != status || c.Reason != reason {
+		t.Errorf("condition %s = %s, %s: %s, want %s, %s", conditionType, c.Status, c.Reason, c.Message, status, reason)
+	}
+}
+
+func wantRequest(t *testing.T, server *fake.Server, request string) {
+	for _, r := range server.Requests() {
+		if r == request {
+			return
+		}
+	}
+	t.Errorf("%s was not requested, requests: %v", request, server.Requests())
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name string
+		rdbc *rdbcv1beta1.Rdbc
+		// bdbs are the dbs on the fake cluster
+		bdbs   []redisenterprise.Bdb
+		faults []fake.Fault
+		// failRdbcUpdate fails the Rdbc metadata and spec updates
+		failRdbcUpdate bool
+		deletionPolicy string
+		// wantErrs has the expected error of each Reconcile call, the Rdbc is reconciled len(wantErrs) times
+		wantErrs []bool
+		check    func(t *testing.T, c client.Client, server *fake.Server)
+	}{
+		{
+			name:           "creates the db",
+			rdbc:           newTestRdbc(nil),
+			deletionPolicy: rdbcv1beta1.DeletionPolicyDelete,
+			wantErrs:       []bool{false, false, false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				bdbs := server.Bdbs()
+				if len(bdbs) != 1 || bdbs[0].Name != testName || bdbs[0].MemorySize != redisenterprise.MegabytesToBytes(100) {
+					t.Fatalf("bdbs = %+v, want a single 100MB db named %s", bdbs, testName)
+				}
+				rdbc := getRdbc(t, c)
+				if rdbc.Annotations[dbUidAnnotation] != fmt.Sprint(bdbs[0].Uid) || rdbc.Annotations[clusterAnnotation] != testCluster {
+					t.Errorf("annotations = %v, want dbuid %d on cluster %s", rdbc.Annotations, bdbs[0].Uid, testCluster)
+				}
+				if !contains(rdbc.Finalizers, rdbcFinalizer) {
+					t.Errorf("finalizers = %v, want %s", rdbc.Finalizers, rdbcFinalizer)
+				}
+				if rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseReady || rdbc.Status.PendingAction != "" {
+					t.Errorf("phase = %s, pending action = %q, want Ready with no pending action", rdbc.Status.Phase, rdbc.Status.PendingAction)
+				}
+				if rdbc.Status.DbUid != bdbs[0].Uid || rdbc.Status.Cluster != testCluster || rdbc.Status.ObservedGeneration != 1 {
+					t.Errorf("status = %+v, want dbUid %d, cluster %s, observed generation 1", rdbc.Status, bdbs[0].Uid, testCluster)
+				}
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionReady, corev1.ConditionTrue, ReasonDbReady)
+				secret := &corev1.Secret{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: testName, Namespace: testNamespace}, secret); err != nil {
+					t.Fatalf("failed to get the connection Secret: %v", err)
+				}
+				if secret.StringData["endpoint"] != rdbc.Status.Endpoint || secret.StringData["password"] != bdbs[0].Password {
+					t.Errorf("Secret data = %v, want the db endpoint %s and password", secret.StringData, rdbc.Status.Endpoint)
+				}
+				if len(bdbs[0].Password) != passwordgen.DefaultLength {
+					t.Errorf("db password length = %d, want a generated password", len(bdbs[0].Password))
+				}
+			},
+		},
+		{
+			name: "adopts the existing db",
+			rdbc: newTestRdbc(func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Spec.AdoptFrom = &rdbcv1beta1.AdoptFrom{Uid: 7}
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			wantErrs: []bool{false, false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				if rdbc.Annotations[dbUidAnnotation] != "7" {
+					t.Errorf("annotations = %v, want dbuid 7", rdbc.Annotations)
+				}
+				if adoption := rdbc.Status.Adoption; adoption == nil || !adoption.Adopted || adoption.DbUid != 7 {
+					t.Errorf("status.adoption = %+v, want dbid 7 adopted", adoption)
+				}
+				if rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseReady {
+					t.Errorf("phase = %s, want Ready", rdbc.Status.Phase)
+				}
+				if len(server.Bdbs()) != 1 {
+					t.Errorf("bdbs = %+v, want the adopted db only", server.Bdbs())
+				}
+				for _, r := range server.Requests() {
+					if r == "POST /v1/bdbs" || r == "PUT /v1/bdbs/7" {
+						t.Errorf("%s requested, the adopted db matching the spec must be left untouched", r)
+					}
+				}
+			},
+		},
+		{
+			name: "refuses the adoption which disables replication",
+			rdbc: newTestRdbc(func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Spec.AdoptFrom = &rdbcv1beta1.AdoptFrom{Name: testName}
+			}),
+			bdbs: []redisenterprise.Bdb{activeBdb(7, func(bdb *redisenterprise.Bdb) {
+				bdb.Replication = redisenterprise.Bool(true)
+			})},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				if _, ok := rdbc.Annotations[dbUidAnnotation]; ok {
+					t.Errorf("annotations = %v, want the refused db not annotated", rdbc.Annotations)
+				}
+				if adoption := rdbc.Status.Adoption; adoption == nil || adoption.Adopted || len(adoption.Rejected) == 0 {
+					t.Errorf("status.adoption = %+v, want not adopted with the rejected changes", adoption)
+				}
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionReady, corev1.ConditionFalse, ReasonAdoptionRefused)
+				if bdb, _ := server.Bdb(7); !redisenterprise.BoolValue(bdb.Replication) {
+					t.Errorf("replication of the refused db was disabled")
+				}
+			},
+		},
+		{
+			name: "finalizer deletes the db",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				now := metav1.Now()
+				rdbc.DeletionTimestamp = &now
+				rdbc.Spec.DeletionPolicy = rdbcv1beta1.DeletionPolicyDelete
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil), activeBdb(8, func(bdb *redisenterprise.Bdb) { bdb.Name = "other" })},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				if _, ok := server.Bdb(7); ok {
+					t.Errorf("dbid: 7 is not deleted")
+				}
+				if _, ok := server.Bdb(8); !ok {
+					t.Errorf("dbid: 8 of another Rdbc is deleted")
+				}
+				rdbc := getRdbc(t, c)
+				if contains(rdbc.Finalizers, rdbcFinalizer) {
+					t.Errorf("finalizers = %v, want the finalizer removed", rdbc.Finalizers)
+				}
+				if rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseDeleting {
+					t.Errorf("phase = %s, want Deleting", rdbc.Status.Phase)
+				}
+			},
+		},
+		{
+			name: "finalizer retains the db",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				now := metav1.Now()
+				rdbc.DeletionTimestamp = &now
+				rdbc.Spec.DeletionPolicy = rdbcv1beta1.DeletionPolicyRetain
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				if _, ok := server.Bdb(7); !ok {
+					t.Errorf("the retained dbid: 7 is deleted")
+				}
+				if rdbc := getRdbc(t, c); contains(rdbc.Finalizers, rdbcFinalizer) {
+					t.Errorf("finalizers = %v, want the finalizer removed", rdbc.Finalizers)
+				}
+			},
+		},
+		{
+			name: "deletion of the db on a removed cluster is blocked",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				now := metav1.Now()
+				rdbc.DeletionTimestamp = &now
+				rdbc.Annotations[clusterAnnotation] = "removed"
+				rdbc.Spec.DeletionPolicy = rdbcv1beta1.DeletionPolicyDelete
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				if !contains(rdbc.Finalizers, rdbcFinalizer) {
+					t.Errorf("finalizers = %v, want the finalizer kept", rdbc.Finalizers)
+				}
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionClusterUnavailable, corev1.ConditionTrue, ReasonClusterNotFound)
+				if len(server.Requests()) != 0 {
+					t.Errorf("requests = %v, want no requests to the other cluster", server.Requests())
+				}
+			},
+		},
+		{
+			name: "deletion of the Rdbc with no db on a removed cluster isn't blocked",
+			rdbc: newTestRdbc(func(rdbc *rdbcv1beta1.Rdbc) {
+				now := metav1.Now()
+				rdbc.DeletionTimestamp = &now
+				rdbc.Finalizers = []string{rdbcFinalizer}
+				rdbc.Spec.ClusterRef = "removed"
+			}),
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				if rdbc := getRdbc(t, c); contains(rdbc.Finalizers, rdbcFinalizer) {
+					t.Errorf("finalizers = %v, want the finalizer removed", rdbc.Finalizers)
+				}
+			},
+		},
+		{
+			name: "removed cluster is reported",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Annotations[clusterAnnotation] = "removed"
+			}),
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionClusterUnavailable, corev1.ConditionTrue, ReasonClusterNotFound)
+				if !contains(rdbc.Finalizers, rdbcFinalizer) {
+					t.Errorf("finalizers = %v, want the finalizer kept", rdbc.Finalizers)
+				}
+			},
+		},
+		{
+			name: "recovered cluster is reported",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Status.SetCondition(rdbcv1beta1.RdbcConditionClusterUnavailable, corev1.ConditionTrue, ReasonClusterConfigError, "no credentials")
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			wantErrs: []bool{false, false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionClusterUnavailable, corev1.ConditionFalse, ReasonClusterAvailable)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionReady, corev1.ConditionTrue, ReasonDbReady)
+			},
+		},
+		{
+			name:           "syncCR deletes the new db if the Rdbc can't be annotated",
+			rdbc:           newTestRdbc(func(rdbc *rdbcv1beta1.Rdbc) { rdbc.Finalizers = []string{rdbcFinalizer} }),
+			failRdbcUpdate: true,
+			deletionPolicy: rdbcv1beta1.DeletionPolicyDelete,
+			wantErrs:       []bool{true},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				if bdbs := server.Bdbs(); len(bdbs) != 0 {
+					t.Errorf("bdbs = %+v, want the new db rolled back", bdbs)
+				}
+				wantRequest(t, server, "POST /v1/bdbs")
+				if rdbc := getRdbc(t, c); rdbc.Annotations[dbUidAnnotation] != "" {
+					t.Errorf("annotations = %v, want no dbuid", rdbc.Annotations)
+				}
+			},
+		},
+		{
+			name:           "create failure is retried",
+			rdbc:           newTestRdbc(nil),
+			faults:         []fake.Fault{{Method: "POST", Path: "/v1/bdbs", StatusCode: 500, Times: 1}},
+			deletionPolicy: rdbcv1beta1.DeletionPolicyDelete,
+			wantErrs:       []bool{true, false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				if bdbs := server.Bdbs(); len(bdbs) != 1 {
+					t.Errorf("bdbs = %+v, want the db created on the retry", bdbs)
+				}
+				rdbc := getRdbc(t, c)
+				if rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseProvisioning {
+					t.Errorf("phase = %s, want Provisioning", rdbc.Status.Phase)
+				}
+			},
+		},
+		{
+			name:           "create failure is reported",
+			rdbc:           newTestRdbc(nil),
+			faults:         []fake.Fault{{Method: "POST", Path: "/v1/bdbs", StatusCode: 500}},
+			deletionPolicy: rdbcv1beta1.DeletionPolicyDelete,
+			wantErrs:       []bool{true},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				if rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseFailed {
+					t.Errorf("phase = %s, want Failed", rdbc.Status.Phase)
+				}
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, ReasonCreateFailed)
+				if _, ok := rdbc.Annotations[dbUidAnnotation]; ok {
+					t.Errorf("annotations = %v, want no dbuid", rdbc.Annotations)
+				}
+			},
+		},
+		{
+			name:     "malformed API response is reported",
+			rdbc:     managed(7, nil),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			faults:   []fake.Fault{{Method: "GET", Path: "/v1/bdbs/7", MalformedJSON: true, Times: 1}},
+			wantErrs: []bool{true},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				wantCondition(t, getRdbc(t, c), rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, ReasonRedisApiError)
+			},
+		},
+		{
+			name: "rotated password is saved once the db accepts it",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Annotations[rotatePasswordAnnotation] = ""
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, func(bdb *redisenterprise.Bdb) { bdb.Password = "old" })},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				bdb, _ := server.Bdb(7)
+				if bdb.Password == "old" {
+					t.Fatalf("the db password is not rotated")
+				}
+				secret := &corev1.Secret{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: testName + passwordSecretSuffix, Namespace: testNamespace}, secret); err != nil {
+					t.Fatalf("failed to get the password Secret: %v", err)
+				}
+				if secret.StringData[passwordSecretKey] != bdb.Password {
+					t.Errorf("the password Secret doesn't hold the db password")
+				}
+				rdbc := getRdbc(t, c)
+				if _, ok := rdbc.Annotations[rotatePasswordAnnotation]; ok || rdbc.Status.LastPasswordRotation == nil {
+					t.Errorf("annotations = %v, last rotation = %v, want the rotation recorded", rdbc.Annotations, rdbc.Status.LastPasswordRotation)
+				}
+			},
+		},
+		{
+			name: "rotated password rejected by the db isn't saved",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Annotations[rotatePasswordAnnotation] = ""
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, func(bdb *redisenterprise.Bdb) { bdb.Password = "old" })},
+			faults:   []fake.Fault{{Method: "PUT", Path: "/v1/bdbs/7", StatusCode: 500}},
+			wantErrs: []bool{true},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				if bdb, _ := server.Bdb(7); bdb.Password != "old" {
+					t.Errorf("the db password is changed")
+				}
+				secret := &corev1.Secret{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: testName + passwordSecretSuffix, Namespace: testNamespace}, secret); err == nil {
+					t.Errorf("the password Secret is saved with the rejected password")
+				}
+				rdbc := getRdbc(t, c)
+				if rdbc.Status.LastPasswordRotation != nil {
+					t.Errorf("last rotation = %v, want no rotation recorded", rdbc.Status.LastPasswordRotation)
+				}
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, ReasonRotationFailed)
+			},
+		},
+		{
+			name: "report policy applies only the spec fields changed since the last applied spec",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Generation = 2
+				rdbc.Status.ObservedGeneration = 1
+				rdbc.Spec.DriftPolicy = rdbcv1beta1.DriftPolicyReport
+				setAppliedSpec(rdbc, nil)
+				rdbc.Spec.Size = 200
+			}),
+			bdbs: []redisenterprise.Bdb{activeBdb(7, func(bdb *redisenterprise.Bdb) {
+				bdb.EvictionPolicy = rdbcv1beta1.EvictionPolicyAllKeysLru
+			})},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				bdb, _ := server.Bdb(7)
+				if bdb.MemorySize != redisenterprise.MegabytesToBytes(200) {
+					t.Errorf("db memory size = %d, want the changed size applied", bdb.MemorySize)
+				}
+				if bdb.EvictionPolicy != rdbcv1beta1.EvictionPolicyAllKeysLru {
+					t.Errorf("db eviction policy = %s, want the drifted policy kept", bdb.EvictionPolicy)
+				}
+				rdbc := getRdbc(t, c)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionTrue, ReasonDriftDetected)
+				if last := appliedSpec(rdbc); last == nil || last.Size != 200 {
+					t.Errorf("applied spec = %+v, want the size 200 recorded", last)
+				}
+			},
+		},
+		{
+			name: "out of band rename is reported",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Status.ObservedGeneration = 1
+				setAppliedSpec(rdbc, nil)
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, func(bdb *redisenterprise.Bdb) { bdb.Name = "renamed" })},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionTrue, ReasonDriftDetected)
+				if c := rdbc.Status.GetCondition(rdbcv1beta1.RdbcConditionDrifted); c != nil && !strings.Contains(c.Message, "name: renamed -> "+testName) {
+					t.Errorf("drifted message = %s, want the name drift", c.Message)
+				}
+			},
+		},
+		{
+			name: "rejected rename isn't reported as drift",
+			rdbc: managed(7, func(rdbc *rdbcv1beta1.Rdbc) {
+				rdbc.Status.ObservedGeneration = 1
+				setAppliedSpec(rdbc, nil)
+				rdbc.Spec.Name = "renamed"
+			}),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			wantErrs: []bool{false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionFalse, ReasonNoDrift)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, ReasonChangesRejected)
+				if last := appliedSpec(rdbc); last == nil || last.Name != testName {
+					t.Errorf("applied spec = %+v, want the db name kept", last)
+				}
+			},
+		},
+		{
+			name:     "slow API times out",
+			rdbc:     managed(7, nil),
+			bdbs:     []redisenterprise.Bdb{activeBdb(7, nil)},
+			faults:   []fake.Fault{{Method: "GET", Path: "/v1/bdbs/7", Latency: 2 * testTimeout, Times: 1}},
+			wantErrs: []bool{true, false},
+			check: func(t *testing.T, c client.Client, server *fake.Server) {
+				rdbc := getRdbc(t, c)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionReady, corev1.ConditionTrue, ReasonDbReady)
+				wantCondition(t, rdbc, rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionFalse, ReasonDbReady)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fake.NewServer()
+			defer server.Close()
+			for _, bdb := range tt.bdbs {
+				server.AddBdb(bdb)
+			}
+			for _, fault := range tt.faults {
+				server.InjectFault(fault)
+			}
+
+			s := runtime.NewScheme()
+			if err := corev1.AddToScheme(s); err != nil {
+				t.Fatalf("failed to register core/v1: %v", err)
+			}
+			if err := apis.AddToScheme(s); err != nil {
+				t.Fatalf("failed to register the Rdbc APIs: %v", err)
+			}
+			cluster := &rdbcv1alpha1.RedisEnterpriseCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: testCluster},
+				Spec:       rdbcv1alpha1.RedisEnterpriseClusterSpec{APIUrl: server.URL, Default: true},
+			}
+			var c client.Client = fakeclient.NewFakeClientWithScheme(s, tt.rdbc, cluster)
+			if tt.failRdbcUpdate {
+				c = &failingUpdateClient{Client: c}
+			}
+			deletionPolicy := tt.deletionPolicy
+			if deletionPolicy == "" {
+				deletionPolicy = rdbcv1beta1.DeletionPolicyRetain
+			}
+			r := &ReconcileRdbc{
+				client: c,
+				scheme: s,
+				redisClient: func(cluster *rdbcv1alpha1.RedisEnterpriseCluster) (redisenterprise.Client, error) {
+					return redisenterprise.NewClient(server.URL, fake.Username, fake.Password, nil, testTimeout), nil
+				},
+				passwordPolicy:        rdbcv1beta1.PasswordPolicy{Length: passwordgen.DefaultLength, Charset: passwordgen.DefaultCharset},
+				defaultDeletionPolicy: deletionPolicy,
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: testName, Namespace: testNamespace}}
+			for i, wantErr := range tt.wantErrs {
+				if _, err := r.Reconcile(request); (err != nil) != wantErr {
+					t.Fatalf("Reconcile() #%d error = %v, wantErr %v", i+1, err, wantErr)
+				}
+			}
+			tt.check(t, c, server)
+		})
+	}
+}
+
+func TestReconcileNotFound(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatalf("failed to register the Rdbc APIs: %v", err)
+	}
+	r := &ReconcileRdbc{client: fakeclient.NewFakeClientWithScheme(s), scheme: s}
+	result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: testName, Namespace: testNamespace}})
+	if err != nil || result != (reconcile.Result{}) {
+		t.Errorf("Reconcile() = %+v, %v, want no requeue for the deleted Rdbc", result, err)
+	}
+}
@@ -0,0 +1,119 @@
+package rdbc
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deletionPolicyFromEnv returns the operator wide deletion policy, Retain if not set
+func deletionPolicyFromEnv() (string, error) {
+	policy := os.Getenv(defaultDeletionPolicyEnv)
+	switch policy {
+	case "":
+		return rdbcv1beta1.DeletionPolicyRetain, nil
+	case rdbcv1beta1.DeletionPolicyDelete, rdbcv1beta1.DeletionPolicyRetain, rdbcv1beta1.DeletionPolicySnapshot:
+		return policy, nil
+	}
+	return "", fmt.Errorf("%s must be one of %s, %s, %s", defaultDeletionPolicyEnv,
+		rdbcv1beta1.DeletionPolicyDelete, rdbcv1beta1.DeletionPolicyRetain, rdbcv1beta1.DeletionPolicySnapshot)
+}
+
+// deletionPolicy returns the deletion policy of the Rdbc, or the operator wide policy if not set
+func (r *ReconcileRdbc) deletionPolicy(rdbc *rdbcv1beta1.Rdbc) string {
+	if rdbc.Spec.DeletionPolicy != "" {
+		return rdbc.Spec.DeletionPolicy
+	}
+	return r.defaultDeletionPolicy
+}
+
+// exportBeforeDelete exports the db to the export location of the cluster and tracks the export in
+// status.deletionSnapshot, returns true once the export completed and is recorded in the
+// <name>-deletion-snapshot ConfigMap. A failed export is started again, the db is never deleted without the export.
+func (r *ReconcileRdbc) exportBeforeDelete(rdbc *rdbcv1beta1.Rdbc, dbUid int32, redis redisenterprise.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster) (bool, error) {
+	snapshot := rdbc.Status.DeletionSnapshot
+	if snapshot == nil {
+		location, err := redisconfig.ExportLocation(r.client, cluster)
+		if err != nil {
+			return false, err
+		}
+		action, err := redis.ExportBdb(dbUid, location)
+		if err != nil {
+			return false, fmt.Errorf("failed to export dbid: %d: %v", dbUid, err)
+		}
+		now := metav1.Now()
+		rdbc.Status.DeletionSnapshot = &rdbcv1beta1.RdbcSnapshotStatus{
+			ActionUid: action.ActionUid,
+			Location:  location.Describe(),
+			StartTime: &now,
+		}
+		log.Info(fmt.Sprintf("exporting dbid: %d before deletion to %s", dbUid, rdbc.Status.DeletionSnapshot.Location))
+		return false, r.updateRdbcStatus(rdbc)
+	}
+	action, err := redis.GetAction(snapshot.ActionUid)
+	if err != nil && !redisenterprise.IsNotFound(err) {
+		return false, err
+	}
+	// The action is purged by the cluster before the completion was observed, export again
+	if action == nil || action.Status == redisenterprise.ActionStatusFailed || action.Status == redisenterprise.ActionStatusCancelled {
+		rdbc.Status.DeletionSnapshot = nil
+		if err := r.updateRdbcStatus(rdbc); err != nil {
+			return false, err
+		}
+		if action == nil {
+			return false, fmt.Errorf("export action %s of dbid: %d not found, exporting again", snapshot.ActionUid, dbUid)
+		}
+		return false, fmt.Errorf("export action %s of dbid: %d %s: %s, the db is kept", snapshot.ActionUid, dbUid, action.Status, action.Error)
+	}
+	if action.Status != redisenterprise.ActionStatusCompleted {
+		log.Info(fmt.Sprintf("dbid: %d export is %s, progress: %v%%", dbUid, action.Status, action.Progress))
+		return false, nil
+	}
+	now := metav1.Now()
+	snapshot.CompletionTime = &now
+	if err := r.recordDeletionSnapshot(rdbc, dbUid, snapshot); err != nil {
+		return false, err
+	}
+	log.Info(fmt.Sprintf("exported dbid: %d to %s", dbUid, snapshot.Location))
+	return true, r.updateRdbcStatus(rdbc)
+}
+
+// recordDeletionSnapshot saves where the db was exported to the <name>-deletion-snapshot ConfigMap,
+// the ConfigMap is not owned by the Rdbc, thus it's kept once the Rdbc is deleted
+func (r *ReconcileRdbc) recordDeletionSnapshot(rdbc *rdbcv1beta1.Rdbc, dbUid int32, snapshot *rdbcv1beta1.RdbcSnapshotStatus) error {
+	name := rdbc.Name + deletionSnapshotSuffix
+	configMap := &corev1.ConfigMap{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: rdbc.Namespace}, configMap)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	configMap.ObjectMeta.Name = name
+	configMap.ObjectMeta.Namespace = rdbc.Namespace
+	configMap.ObjectMeta.Labels = map[string]string{"app": rdbc.Name}
+	configMap.Data = map[string]string{
+		"dbUid":          fmt.Sprint(dbUid),
+		"dbName":         rdbc.Spec.Name,
+		"location":       snapshot.Location,
+		"actionUid":      snapshot.ActionUid,
+		"completionTime": snapshot.CompletionTime.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if exists {
+		err = r.client.Update(context.TODO(), configMap)
+	} else {
+		err = r.client.Create(context.TODO(), configMap)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record the db export in ConfigMap: %s: %v", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package rdbc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// resyncIntervalFromEnv returns the interval the dbs are compared with the spec at, 0 disables the periodic resync
+func resyncIntervalFromEnv() (time.Duration, error) {
+	value := os.Getenv(resyncIntervalEnv)
+	if value == "" {
+		return defaultResyncInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("%s must be a non negative duration, e.g. 10m, got: %s", resyncIntervalEnv, value)
+	}
+	return interval, nil
+}
+
+// requeueAfter returns the time to the next password rotation, to the next resync or to the next usage read,
+// whichever comes first, 0 if none is enabled
+func (r *ReconcileRdbc) requeueAfter(rdbc *rdbcv1beta1.Rdbc) time.Duration {
+	requeueAfter := passwordRotationRequeueAfter(rdbc)
+	next := func(d time.Duration) {
+		if d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+	next(r.resyncInterval)
+	if r.usageInterval > 0 {
+		// The failed usage read is retried on the next interval
+		if d := r.usageRequeueAfter(rdbc); d > 0 {
+			next(d)
+		} else {
+			next(r.usageInterval)
+		}
+	}
+	return requeueAfter
+}
+
+// describeDrift returns the human readable out of band changes of the db, made by diffRdbcSpec,
+// and the out of band changes of the fields which can't be changed in place, name, modules and shardKeyRegex.
+// The latter are compared with the last applied spec, thus the rejected spec changes aren't reported as drift.
+// The password isn't reported, it follows the password Secret which may change without the spec change.
+func describeDrift(redisDb *redisenterprise.Bdb, update *redisenterprise.Bdb, last *rdbcv1beta1.RdbcSpec) ([]string, []string) {
+	var drift []string
+	if update != nil {
+		changes := *update
+		changes.Password = ""
+		drift = describeBdbUpdate(redisDb, &changes)
+	}
+	if last == nil {
+		return drift, nil
+	}
+	var immutable []string
+	if redisDb.Name != last.Name {
+		immutable = append(immutable, fmt.Sprintf("name: %s -> %s", redisDb.Name, last.Name))
+	}
+	if !equalModules(last.Modules, redisDb.ModuleList) {
+		immutable = append(immutable, fmt.Sprintf("modules: %v -> %v", describeModules(dbModules(redisDb)), describeModules(last.Modules)))
+	}
+	if redisDb.ShardsCount > 1 && last.ShardsCount > 1 && !equalShardKeyRegex(last.ShardKeyRegex, redisDb.ShardKeyRegex) {
+		immutable = append(immutable, fmt.Sprintf("shardKeyRegex: %v -> %v", dbShardKeyRegex(redisDb), last.ShardKeyRegex))
+	}
+	return drift, immutable
+}
+
+// splitSpecChanges splits the update into the changes of the spec fields which changed since the last applied spec
+// and the out of band changes of the db, the password changes are always part of the spec changes
+func splitSpecChanges(update *redisenterprise.Bdb, last *rdbcv1beta1.RdbcSpec, spec *rdbcv1beta1.RdbcSpec) (*redisenterprise.Bdb, *redisenterprise.Bdb) {
+	if update == nil {
+		return nil, nil
+	}
+	changes := &redisenterprise.Bdb{Password: update.Password}
+	drift := *update
+	drift.Password = ""
+	if last.Size != spec.Size {
+		changes.MemorySize, drift.MemorySize = update.MemorySize, 0
+	}
+	if last.Replication != spec.Replication {
+		changes.Replication, drift.Replication = update.Replication, nil
+	}
+	if last.ShardsCount != spec.ShardsCount {
+		changes.ShardsCount, drift.ShardsCount = update.ShardsCount, 0
+		changes.Sharding, drift.Sharding = update.Sharding, nil
+		changes.ShardKeyRegex, drift.ShardKeyRegex = update.ShardKeyRegex, nil
+	}
+	if last.OSSCluster != spec.OSSCluster {
+		changes.OSSCluster, drift.OSSCluster = update.OSSCluster, nil
+	}
+	if last.ProxyPolicy != spec.ProxyPolicy {
+		changes.ProxyPolicy, drift.ProxyPolicy = update.ProxyPolicy, ""
+	}
+	persistenceChanged := last.DataPersistence != spec.DataPersistence
+	if persistenceChanged {
+		changes.DataPersistence, drift.DataPersistence = update.DataPersistence, ""
+	}
+	if persistenceChanged || last.AofPolicy != spec.AofPolicy {
+		changes.AofPolicy, drift.AofPolicy = update.AofPolicy, ""
+	}
+	if persistenceChanged || !reflect.DeepEqual(last.SnapshotPolicy, spec.SnapshotPolicy) {
+		changes.SnapshotPolicy, drift.SnapshotPolicy = update.SnapshotPolicy, nil
+	}
+	if last.EvictionPolicy != spec.EvictionPolicy {
+		changes.EvictionPolicy, drift.EvictionPolicy = update.EvictionPolicy, ""
+	}
+	return nonEmptyUpdate(changes), nonEmptyUpdate(&drift)
+}
+
+// nonEmptyUpdate returns nil if the update changes nothing
+func nonEmptyUpdate(update *redisenterprise.Bdb) *redisenterprise.Bdb {
+	if reflect.DeepEqual(update, &redisenterprise.Bdb{}) {
+		return nil
+	}
+	return update
+}
+
+// appliedSpec returns the spec last applied to the db, nil if it's not recorded yet or can't be parsed
+func appliedSpec(rdbc *rdbcv1beta1.Rdbc) *rdbcv1beta1.RdbcSpec {
+	value, ok := rdbc.Annotations[appliedSpecAnnotation]
+	if !ok {
+		return nil
+	}
+	spec := &rdbcv1beta1.RdbcSpec{}
+	if err := json.Unmarshal([]byte(value), spec); err != nil {
+		log.Error(err, "failed to parse the applied spec annotation", "Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+		return nil
+	}
+	return spec
+}
+
+// recordAppliedSpec sets the applied spec annotation to the spec applied to the db,
+// the fields which can't be changed in place keep their last applied values, or the db values if none was recorded.
+// Returns true if the annotation is changed.
+func recordAppliedSpec(rdbc *rdbcv1beta1.Rdbc, spec *rdbcv1beta1.RdbcSpec, last *rdbcv1beta1.RdbcSpec, redisDb *redisenterprise.Bdb) (bool, error) {
+	applied := spec.DeepCopy()
+	if applied.Name != redisDb.Name {
+		applied.Name = redisDb.Name
+		if last != nil {
+			applied.Name = last.Name
+		}
+	}
+	if !equalModules(applied.Modules, redisDb.ModuleList) {
+		applied.Modules = dbModules(redisDb)
+		if last != nil {
+			applied.Modules = last.Modules
+		}
+	}
+	if redisDb.ShardsCount > 1 && !equalShardKeyRegex(applied.ShardKeyRegex, redisDb.ShardKeyRegex) {
+		applied.ShardKeyRegex = dbShardKeyRegex(redisDb)
+		if last != nil {
+			applied.ShardKeyRegex = last.ShardKeyRegex
+		}
+	}
+	value, err := json.Marshal(applied)
+	if err != nil {
+		return false, err
+	}
+	if rdbc.Annotations[appliedSpecAnnotation] == string(value) {
+		return false, nil
+	}
+	if rdbc.Annotations == nil {
+		rdbc.Annotations = map[string]string{}
+	}
+	rdbc.Annotations[appliedSpecAnnotation] = string(value)
+	return true, nil
+}
+
+// dbModules returns the modules loaded by the db in the spec form
+func dbModules(redisDb *redisenterprise.Bdb) []rdbcv1beta1.RdbcModule {
+	var modules []rdbcv1beta1.RdbcModule
+	for _, m := range redisDb.ModuleList {
+		modules = append(modules, rdbcv1beta1.RdbcModule{Name: m.ModuleName, Version: m.SemanticVersion, Args: m.ModuleArgs})
+	}
+	return modules
+}
+
+// dbShardKeyRegex returns the shard key regexes of the db in the spec form
+func dbShardKeyRegex(redisDb *redisenterprise.Bdb) []string {
+	var regexes []string
+	for _, keyRegex := range redisDb.ShardKeyRegex {
+		regexes = append(regexes, keyRegex.Regex)
+	}
+	return regexes
+}
+
+// describeModules returns the module names with the versions, e.g. [search 2.0.0 ReJSON]
+func describeModules(modules []rdbcv1beta1.RdbcModule) []string {
+	var descriptions []string
+	for _, module := range modules {
+		descriptions = append(descriptions, moduleDescription(module.Name, module.Version))
+	}
+	return descriptions
+}
+
+// passwordUpdate returns the password part of the update, nil if the password is up to date
+func passwordUpdate(update *redisenterprise.Bdb) *redisenterprise.Bdb {
+	if update == nil || update.Password == "" {
+		return nil
+	}
+	return &redisenterprise.Bdb{Password: update.Password}
+}
+
+// setDriftCondition reports the out of band changes of the db, the message of the last correction
+// is kept until the next drift. The drift of the fields which can't be changed in place is never corrected.
+func setDriftCondition(status *rdbcv1beta1.RdbcStatus, policy string, drift []string, immutable []string) {
+	switch {
+	case len(drift) == 0 && len(immutable) == 0:
+		if c := status.GetCondition(rdbcv1beta1.RdbcConditionDrifted); c == nil || c.Status == corev1.ConditionTrue {
+			status.SetCondition(rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionFalse, ReasonNoDrift, "")
+		}
+	case policy == rdbcv1beta1.DriftPolicyReport:
+		message := fmt.Sprintf("db settings differ from the spec (db -> spec): %s", strings.Join(append(drift, immutable...), "; "))
+		status.SetCondition(rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionTrue, ReasonDriftDetected, message)
+	case len(immutable) > 0:
+		message := fmt.Sprintf("db settings differ from the spec and can't be corrected in place (db -> spec): %s", strings.Join(immutable, "; "))
+		if len(drift) > 0 {
+			message += fmt.Sprintf(", corrected: %s", strings.Join(drift, "; "))
+		}
+		status.SetCondition(rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionTrue, ReasonDriftDetected, message)
+	default:
+		message := fmt.Sprintf("db settings were corrected to the spec (db -> spec): %s", strings.Join(drift, "; "))
+		status.SetCondition(rdbcv1beta1.RdbcConditionDrifted, corev1.ConditionFalse, ReasonDriftCorrected, message)
+	}
+}
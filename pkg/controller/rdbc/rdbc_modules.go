@@ -0,0 +1,148 @@
+package rdbc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+)
+
+// moduleAliases maps the well known module product names to the Redis Enterprise module names
+var moduleAliases = map[string]string{
+	"redisearch":      "search",
+	"redisjson":       "rejson",
+	"json":            "rejson",
+	"redistimeseries": "timeseries",
+	"redisbloom":      "bf",
+	"bloom":           "bf",
+}
+
+// unknownModulesError is returned when the requested modules are not installed on the cluster
+type unknownModulesError struct {
+	unknown   []string
+	installed []string
+}
+
+func (e *unknownModulesError) Error() string {
+	return fmt.Sprintf("modules are not installed on the cluster: %s, installed modules: %s",
+		strings.Join(e.unknown, ", "),
+		strings.Join(e.installed, ", "))
+}
+
+// resolveModules checks the requested modules against the modules installed on the cluster
+// and returns the module list for the db creation
+func resolveModules(modules []rdbcv1beta1.RdbcModule, redis redisenterprise.Client) ([]redisenterprise.BdbModule, error) {
+	if len(modules) == 0 {
+		return nil, nil
+	}
+	installed, err := redis.ListModules()
+	if err != nil {
+		log.Error(err, "failed to list cluster modules")
+		return nil, err
+	}
+	var moduleList []redisenterprise.BdbModule
+	var unknown []string
+	for _, module := range modules {
+		installedModule := findModule(module, installed)
+		if installedModule == nil {
+			unknown = append(unknown, moduleDescription(module.Name, module.Version))
+			continue
+		}
+		moduleList = append(moduleList, redisenterprise.BdbModule{
+			ModuleId:        installedModule.Uid,
+			ModuleName:      installedModule.ModuleName,
+			ModuleArgs:      module.Args,
+			SemanticVersion: installedModule.SemanticVersion,
+		})
+	}
+	if len(unknown) > 0 {
+		e := &unknownModulesError{unknown: unknown}
+		for _, m := range installed {
+			e.installed = append(e.installed, moduleDescription(m.ModuleName, m.SemanticVersion))
+		}
+		return nil, e
+	}
+	return moduleList, nil
+}
+
+// findModule returns the installed module matching the name and the version,
+// the latest installed version if the version is not set, or nil if no module matches
+func findModule(module rdbcv1beta1.RdbcModule, installed []redisenterprise.Module) *redisenterprise.Module {
+	var found *redisenterprise.Module
+	for i := range installed {
+		m := &installed[i]
+		if !moduleNameMatches(module.Name, m.ModuleName) {
+			continue
+		}
+		if module.Version != "" {
+			if m.SemanticVersion == module.Version {
+				return m
+			}
+			continue
+		}
+		if found == nil || compareVersions(m.SemanticVersion, found.SemanticVersion) > 0 {
+			found = m
+		}
+	}
+	return found
+}
+
+// moduleNameMatches compares the module names case insensitive, resolving the well known aliases
+func moduleNameMatches(name string, moduleName string) bool {
+	name = strings.ToLower(name)
+	if alias, ok := moduleAliases[name]; ok {
+		name = alias
+	}
+	return name == strings.ToLower(moduleName)
+}
+
+// equalModules returns true if the db loads the same modules as requested by the spec
+func equalModules(modules []rdbcv1beta1.RdbcModule, moduleList []redisenterprise.BdbModule) bool {
+	if len(modules) != len(moduleList) {
+		return false
+	}
+	for _, module := range modules {
+		found := false
+		for _, m := range moduleList {
+			if moduleNameMatches(module.Name, m.ModuleName) && (module.Version == "" || module.Version == m.SemanticVersion) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func moduleDescription(name string, version string) string {
+	if version == "" {
+		return name
+	}
+	return fmt.Sprintf("%s %s", name, version)
+}
+
+// compareVersions compares dot separated numeric versions, returns 1, 0 or -1
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		if aNum > bNum {
+			return 1
+		}
+		if aNum < bNum {
+			return -1
+		}
+	}
+	return 0
+}
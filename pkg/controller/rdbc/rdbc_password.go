@@ -0,0 +1,223 @@
+package rdbc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/passwordgen"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// migratePassword moves the plaintext password of the v1alpha1 Rdbc, kept in the password annotation, to a Secret
+// and references it by spec.passwordSecretRef, returns true if the CR was updated.
+// The annotation is dropped if spec.passwordSecretRef is set already. The password is scrubbed from
+// the kubectl last applied configuration as well. The password value is never logged.
+func (r *ReconcileRdbc) migratePassword(rdbc *rdbcv1beta1.Rdbc) (bool, error) {
+	password, ok := rdbc.Annotations[rdbcv1beta1.PasswordAnnotation]
+	scrubbed := scrubLastAppliedPassword(rdbc)
+	if !ok && !scrubbed {
+		return false, nil
+	}
+	if !ok {
+		log.Info("removing spec.password of v1alpha1 from the last applied configuration",
+			"Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+	} else if rdbc.Spec.PasswordSecretRef != nil {
+		log.Info("spec.password of v1alpha1 is dropped since spec.passwordSecretRef is set",
+			"Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+	} else if password != "" {
+		secretName := rdbc.Name + passwordSecretSuffix
+		log.Info("spec.password of v1alpha1 is deprecated, moving the password to a Secret",
+			"Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name, "Secret.Name", secretName)
+		ref := &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  passwordSecretKey,
+		}
+		if err := r.savePassword(rdbc, ref, password); err != nil {
+			return false, err
+		}
+		rdbc.Spec.PasswordSecretRef = ref
+	}
+	delete(rdbc.Annotations, rdbcv1beta1.PasswordAnnotation)
+	if err := r.updateRdbc(rdbc); err != nil {
+		return false, fmt.Errorf("failed to remove the v1alpha1 password annotation: %v", err)
+	}
+	return true, nil
+}
+
+// scrubLastAppliedPassword removes spec.password from the last applied configuration kept by kubectl apply,
+// returns true if the annotation was changed. The annotation which can't be parsed is removed,
+// kubectl apply sets it again on the next apply.
+func scrubLastAppliedPassword(rdbc *rdbcv1beta1.Rdbc) bool {
+	lastApplied, ok := rdbc.Annotations[corev1.LastAppliedConfigAnnotation]
+	if !ok {
+		return false
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lastApplied), &config); err != nil {
+		log.Info(fmt.Sprintf("removing the last applied configuration which can't be parsed: %v", err),
+			"Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+		delete(rdbc.Annotations, corev1.LastAppliedConfigAnnotation)
+		return true
+	}
+	spec, ok := config["spec"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if _, ok := spec["password"]; !ok {
+		return false
+	}
+	delete(spec, "password")
+	scrubbed, err := json.Marshal(config)
+	if err != nil {
+		delete(rdbc.Annotations, corev1.LastAppliedConfigAnnotation)
+		return true
+	}
+	rdbc.Annotations[corev1.LastAppliedConfigAnnotation] = string(scrubbed)
+	return true
+}
+
+// rotatePassword generates a new db password when the rotation interval elapsed
+// or the rotation is requested by the annotation, returns the new password if the password was rotated.
+// The new password is applied to the db first, then it's saved to the spec.passwordSecretRef Secret,
+// or to the <name>-password Secret if the password was generated by the operator,
+// thus the Secret never holds a password the db rejects. A failed Secret or status write rotates the password again.
+func (r *ReconcileRdbc) rotatePassword(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, redis redisenterprise.Client) (string, error) {
+	_, requested := rdbc.Annotations[rotatePasswordAnnotation]
+	due := rdbc.Spec.PasswordRotation != nil && passwordRotationRequeueAfter(rdbc) == 0
+	if !requested && !due {
+		return "", nil
+	}
+	password, err := r.generatePassword(rdbc)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password: %v", err)
+	}
+	if _, err := redis.UpdateBdb(redisDb.Uid, &redisenterprise.Bdb{Password: password}); err != nil {
+		return "", fmt.Errorf("failed to update the password of dbid: %d: %v", redisDb.Uid, err)
+	}
+	redisDb.Password = password
+	ref := rdbc.Spec.PasswordSecretRef
+	if ref == nil {
+		ref = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: rdbc.Name + passwordSecretSuffix},
+			Key:                  passwordSecretKey,
+		}
+	}
+	if err := r.savePassword(rdbc, ref, password); err != nil {
+		return "", err
+	}
+	// The connection Secret is updated right away, the reconcile may be held before it's written again
+	if _, err := r.manageSecret(rdbc, redisDb); err != nil {
+		return "", err
+	}
+	log.Info("rotated db password", "Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name, "Secret.Name", ref.Name)
+	if rdbc.Spec.PasswordSecretRef == nil || requested {
+		rdbc.Spec.PasswordSecretRef = ref
+		delete(rdbc.Annotations, rotatePasswordAnnotation)
+		if err := r.updateRdbc(rdbc); err != nil {
+			return "", fmt.Errorf("failed to update Rdbc after the password rotation: %v", err)
+		}
+	}
+	now := metav1.Now()
+	rdbc.Status.LastPasswordRotation = &now
+	if err := r.updateRdbcStatus(rdbc); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// generatePassword returns a new db password of the Rdbc policy,
+// the fields unset by the Rdbc are taken from the operator wide policy
+func (r *ReconcileRdbc) generatePassword(rdbc *rdbcv1beta1.Rdbc) (string, error) {
+	password, err := passwordgen.Generate(passwordgen.Merge(r.passwordPolicy, rdbc.Spec.PasswordPolicy))
+	if err != nil {
+		log.Error(err, "failed to generate password")
+		return "", err
+	}
+	return password, nil
+}
+
+// passwordRotationRequeueAfter returns the time left until the next password rotation,
+// zero if the rotation is due or is not enabled
+func passwordRotationRequeueAfter(rdbc *rdbcv1beta1.Rdbc) time.Duration {
+	if rdbc.Spec.PasswordRotation == nil {
+		return 0
+	}
+	last := rdbc.CreationTimestamp
+	if rdbc.Status.LastPasswordRotation != nil {
+		last = *rdbc.Status.LastPasswordRotation
+	}
+	if left := time.Until(last.Add(rdbc.Spec.PasswordRotation.Interval.Duration)); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// savePassword writes the password to the key of the Secret, the other keys are kept,
+// the Secret is created and owned by the Rdbc if it doesn't exist
+func (r *ReconcileRdbc) savePassword(rdbc *rdbcv1beta1.Rdbc, ref *corev1.SecretKeySelector, password string) error {
+	secret := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: rdbc.Namespace}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get the password Secret: %s: %v", ref.Name, err)
+	}
+	if errors.IsNotFound(err) {
+		secret.ObjectMeta.Name = ref.Name
+		secret.ObjectMeta.Namespace = rdbc.Namespace
+		secret.StringData = map[string]string{ref.Key: password}
+		if err := controllerutil.SetControllerReference(rdbc, secret, r.scheme); err != nil {
+			return err
+		}
+		err = r.client.Create(context.TODO(), secret)
+	} else {
+		secret.StringData = map[string]string{ref.Key: password}
+		err = r.client.Update(context.TODO(), secret)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save the password Secret: %s: %v", ref.Name, err)
+	}
+	return nil
+}
+
+// resolvePassword returns the db password from the spec.passwordSecretRef Secret,
+// the not yet migrated v1alpha1 password, or an empty password if the password is not set by user
+func (r *ReconcileRdbc) resolvePassword(rdbc *rdbcv1beta1.Rdbc) (string, error) {
+	ref := rdbc.Spec.PasswordSecretRef
+	if ref == nil {
+		return rdbc.Annotations[rdbcv1beta1.PasswordAnnotation], nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: rdbc.Namespace}, secret); err != nil {
+		return "", fmt.Errorf("failed to get password Secret: %s: %v", ref.Name, err)
+	}
+	password, ok := secret.Data[ref.Key]
+	if !ok || len(password) == 0 {
+		return "", fmt.Errorf("password Secret: %s has no %s key", ref.Name, ref.Key)
+	}
+	return string(password), nil
+}
+
+// rdbcsReferencingPassword returns the requests of the Rdbcs which read the password from the Secret
+func rdbcsReferencingPassword(c client.Client, namespace string, name string) []reconcile.Request {
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, rdbcs); err != nil {
+		log.Error(err, "Failed to list Rdbcs")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rdbc := range rdbcs.Items {
+		if ref := rdbc.Spec.PasswordSecretRef; ref != nil && ref.Name == name {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rdbc.Name, Namespace: rdbc.Namespace}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,58 @@
+package rdbc
+
+import (
+	"testing"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScrubLastAppliedPassword(t *testing.T) {
+	tests := []struct {
+		name         string
+		lastApplied  *string
+		wantScrubbed bool
+		want         *string
+	}{
+		{name: "no annotation"},
+		{
+			name:         "v1alpha1 password",
+			lastApplied:  stringPtr(`{"apiVersion":"rdbc.cnative/v1alpha1","kind":"Rdbc","spec":{"name":"cache","password":"secret","size":100}}` + "\n"),
+			wantScrubbed: true,
+			want:         stringPtr(`{"apiVersion":"rdbc.cnative/v1alpha1","kind":"Rdbc","spec":{"name":"cache","size":100}}`),
+		},
+		{
+			name:        "no password",
+			lastApplied: stringPtr(`{"apiVersion":"rdbc.cnative/v1beta1","kind":"Rdbc","spec":{"name":"cache","size":100}}`),
+			want:        stringPtr(`{"apiVersion":"rdbc.cnative/v1beta1","kind":"Rdbc","spec":{"name":"cache","size":100}}`),
+		},
+		{
+			name:         "malformed annotation",
+			lastApplied:  stringPtr(`{"spec":{"password":"secret"`),
+			wantScrubbed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdbc := &rdbcv1beta1.Rdbc{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+			if tt.lastApplied != nil {
+				rdbc.Annotations[corev1.LastAppliedConfigAnnotation] = *tt.lastApplied
+			}
+			if scrubbed := scrubLastAppliedPassword(rdbc); scrubbed != tt.wantScrubbed {
+				t.Errorf("scrubLastAppliedPassword() = %v, want %v", scrubbed, tt.wantScrubbed)
+			}
+			got, ok := rdbc.Annotations[corev1.LastAppliedConfigAnnotation]
+			if tt.want == nil && ok {
+				t.Errorf("last applied configuration = %s, want the annotation removed", got)
+			}
+			if tt.want != nil && got != *tt.want {
+				t.Errorf("last applied configuration = %s, want %s", got, *tt.want)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,83 @@
+package rdbc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/quota"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// quotaExceededError is returned when the db doesn't fit the RdbcQuotas of the namespace
+type quotaExceededError struct {
+	exceeded []string
+}
+
+func (e *quotaExceededError) Error() string {
+	return strings.Join(e.exceeded, "; ")
+}
+
+// checkQuota checks the desired spec against the RdbcQuotas of the namespace, redisDb is nil before the db is created.
+// The Rdbcs without a db are not counted, thus the Rdbcs admitted over the quota without the webhook don't block each other.
+func (r *ReconcileRdbc) checkQuota(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb) error {
+	current := rdbcv1alpha1.RdbcQuotaUsage{}
+	if redisDb != nil {
+		current = dbQuotaUsage(redisDb)
+	}
+	exceeded, err := quota.Check(context.TODO(), r.client, rdbc, quota.Usage(desiredRdbcSpec(rdbc)), current, true)
+	if err != nil {
+		return err
+	}
+	if len(exceeded) > 0 {
+		return &quotaExceededError{exceeded: exceeded}
+	}
+	return nil
+}
+
+// dbQuotaUsage returns the quota usage of the existing db
+func dbQuotaUsage(redisDb *redisenterprise.Bdb) rdbcv1alpha1.RdbcQuotaUsage {
+	spec := &rdbcv1beta1.RdbcSpec{
+		Size:        redisenterprise.BytesToMegabytes(redisDb.MemorySize),
+		ShardsCount: redisDb.ShardsCount,
+		Replication: redisenterprise.BoolValue(redisDb.Replication),
+	}
+	if spec.ShardsCount == 0 {
+		spec.ShardsCount = rdbcv1beta1.DefaultShardsCount
+	}
+	return quota.Usage(spec)
+}
+
+// setRdbcQuotaExceeded reports the exceeded quota, the Rdbc without a db is failed,
+// the existing db stays as is and the spec changes are held until the quota allows them
+func (r *ReconcileRdbc) setRdbcQuotaExceeded(rdbc *rdbcv1beta1.Rdbc, err error) error {
+	if rdbc.Status.DbUid == 0 {
+		return r.setRdbcError(rdbc, ReasonQuotaExceeded, err)
+	}
+	message := fmt.Sprintf("db is ready, spec changes exceed the quota: %v", err)
+	rdbc.Status.SetCondition(rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, ReasonQuotaExceeded, message)
+	return r.updateRdbcStatus(rdbc)
+}
+
+// rdbcsOverQuota returns the Rdbcs blocked by the quota in the namespace of the RdbcQuota,
+// thus they are retried once the quota is raised
+func rdbcsOverQuota(c client.Client, namespace string) []reconcile.Request {
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, rdbcs); err != nil {
+		log.Error(err, "Failed to list Rdbcs")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rdbc := range rdbcs.Items {
+		if cond := rdbc.Status.GetCondition(rdbcv1beta1.RdbcConditionDegraded); cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == ReasonQuotaExceeded {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rdbc.Name, Namespace: rdbc.Namespace}})
+		}
+	}
+	return requests
+}
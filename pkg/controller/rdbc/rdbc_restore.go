@@ -0,0 +1,46 @@
+package rdbc
+
+import (
+	"context"
+	"fmt"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// runningRestore returns the name of the RdbcRestore importing into the db, empty if there is none
+func (r *ReconcileRdbc) runningRestore(rdbc *rdbcv1beta1.Rdbc) (string, error) {
+	restores := &rdbcv1alpha1.RdbcRestoreList{}
+	if err := r.client.List(context.TODO(), &client.ListOptions{Namespace: rdbc.Namespace}, restores); err != nil {
+		return "", err
+	}
+	for _, restore := range restores.Items {
+		if restore.Spec.RdbcName == rdbc.Name && restore.Status.Phase == rdbcv1alpha1.RestorePhaseRunning {
+			return restore.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// setRdbcWaitingForRestore reports that the spec changes are held until the restore finishes,
+// the db stays ready meanwhile
+func (r *ReconcileRdbc) setRdbcWaitingForRestore(rdbc *rdbcv1beta1.Rdbc, restore string) error {
+	status := &rdbc.Status
+	message := fmt.Sprintf("spec changes wait for RdbcRestore %s to finish", restore)
+	status.SetCondition(rdbcv1beta1.RdbcConditionProvisioning, corev1.ConditionFalse, ReasonRestoreInProgress, message)
+	return r.updateRdbcStatus(rdbc)
+}
+
+// rdbcOfRestore maps the RdbcRestore to its Rdbc, thus the held changes are applied once the restore finishes
+var rdbcOfRestore = handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+	restore, ok := a.Object.(*rdbcv1alpha1.RdbcRestore)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: restore.Spec.RdbcName, Namespace: restore.Namespace}}}
+})
@@ -0,0 +1,125 @@
+package rdbc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setRdbcReady marks the Rdbc as ready with the observed db state,
+// the changes which can't be applied in place are reported as degraded.
+// The status isn't written if it differs from the observed status by the sync time only.
+func (r *ReconcileRdbc) setRdbcReady(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, rejected []string, observed *rdbcv1beta1.RdbcStatus) error {
+	status := &rdbc.Status
+	status.Phase = rdbcv1beta1.RdbcPhaseReady
+	status.ObservedGeneration = rdbc.Generation
+	status.PendingAction = ""
+	setDbStatus(status, redisDb)
+	status.SetCondition(rdbcv1beta1.RdbcConditionReady, corev1.ConditionTrue, ReasonDbReady, "db is ready")
+	status.SetCondition(rdbcv1beta1.RdbcConditionProvisioning, corev1.ConditionFalse, ReasonDbReady, "")
+	if len(rejected) > 0 {
+		message := fmt.Sprintf("db is ready, changes can't be applied in place: %s", strings.Join(rejected, "; "))
+		status.SetCondition(rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, ReasonChangesRejected, message)
+	} else {
+		status.SetCondition(rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionFalse, ReasonDbReady, "")
+	}
+	if !statusChanged(observed, status) {
+		return nil
+	}
+	return r.updateRdbcStatus(rdbc)
+}
+
+// statusChanged returns true if the status differs from the observed status by more than the sync time
+func statusChanged(observed *rdbcv1beta1.RdbcStatus, status *rdbcv1beta1.RdbcStatus) bool {
+	if observed == nil {
+		return true
+	}
+	synced := observed.DeepCopy()
+	synced.LastSyncTime = status.LastSyncTime
+	return !reflect.DeepEqual(synced, status)
+}
+
+// setRdbcAdoptionDryRun reports the changes the spec would make to the existing db,
+// the status is filled from the db settings
+func (r *ReconcileRdbc) setRdbcAdoptionDryRun(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb) error {
+	status := &rdbc.Status
+	status.Phase = rdbcv1beta1.RdbcPhasePending
+	status.ObservedGeneration = rdbc.Generation
+	setDbStatus(status, redisDb)
+	adoption := status.Adoption
+	message := fmt.Sprintf("adoption dry-run of dbid: %d, %d changes would be applied, %d changes can't be applied in place, see status.adoption",
+		redisDb.Uid, len(adoption.Changes), len(adoption.Rejected))
+	status.SetCondition(rdbcv1beta1.RdbcConditionReady, corev1.ConditionFalse, ReasonAdoptionDryRun, message)
+	status.SetCondition(rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionFalse, ReasonAdoptionDryRun, "")
+	return r.updateRdbcStatus(rdbc)
+}
+
+// setDbStatus sets the observed db settings on the status
+func setDbStatus(status *rdbcv1beta1.RdbcStatus, redisDb *redisenterprise.Bdb) {
+	now := metav1.Now()
+	status.DbUid = redisDb.Uid
+	status.Endpoint = dbEndpoint(redisDb)
+	status.MemorySize = redisenterprise.BytesToMegabytes(redisDb.MemorySize)
+	status.LastSyncTime = &now
+	status.Persistence = &rdbcv1beta1.RdbcPersistenceStatus{
+		DataPersistence: redisDb.DataPersistence,
+		AofPolicy:       redisDb.AofPolicy,
+		EvictionPolicy:  redisDb.EvictionPolicy,
+	}
+	for _, policy := range redisDb.SnapshotPolicy {
+		status.Persistence.SnapshotPolicy = append(status.Persistence.SnapshotPolicy, rdbcv1beta1.SnapshotPolicy{Secs: policy.Secs, Writes: policy.Writes})
+	}
+}
+
+// setRdbcProvisioning marks the Rdbc as provisioning while waiting for the action to complete
+func (r *ReconcileRdbc) setRdbcProvisioning(rdbc *rdbcv1beta1.Rdbc, actionUid string, message string) error {
+	status := &rdbc.Status
+	status.Phase = rdbcv1beta1.RdbcPhaseProvisioning
+	status.PendingAction = actionUid
+	status.SetCondition(rdbcv1beta1.RdbcConditionReady, corev1.ConditionFalse, ReasonProvisioning, message)
+	status.SetCondition(rdbcv1beta1.RdbcConditionProvisioning, corev1.ConditionTrue, ReasonProvisioning, message)
+	status.SetCondition(rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionFalse, ReasonProvisioning, "")
+	return r.updateRdbcStatus(rdbc)
+}
+
+// setRdbcError marks the Rdbc as failed with the reconcile error
+func (r *ReconcileRdbc) setRdbcError(rdbc *rdbcv1beta1.Rdbc, reason string, err error) error {
+	status := &rdbc.Status
+	status.Phase = rdbcv1beta1.RdbcPhaseFailed
+	message := fmt.Sprintf("%v", err)
+	status.SetCondition(rdbcv1beta1.RdbcConditionReady, corev1.ConditionFalse, reason, message)
+	status.SetCondition(rdbcv1beta1.RdbcConditionDegraded, corev1.ConditionTrue, reason, message)
+	return r.updateRdbcStatus(rdbc)
+}
+
+// setRdbcClusterUnavailable marks the Rdbc as failed since its Redis Enterprise cluster can't be used
+func (r *ReconcileRdbc) setRdbcClusterUnavailable(rdbc *rdbcv1beta1.Rdbc, reason string, err error) error {
+	rdbc.Status.SetCondition(rdbcv1beta1.RdbcConditionClusterUnavailable, corev1.ConditionTrue, reason, err.Error())
+	return r.setRdbcError(rdbc, reason, err)
+}
+
+// setRdbcDeleting marks the Rdbc as being deleted
+func (r *ReconcileRdbc) setRdbcDeleting(rdbc *rdbcv1beta1.Rdbc) error {
+	status := &rdbc.Status
+	status.Phase = rdbcv1beta1.RdbcPhaseDeleting
+	message := "db is being deleted"
+	status.SetCondition(rdbcv1beta1.RdbcConditionReady, corev1.ConditionFalse, ReasonDbDeleting, message)
+	status.SetCondition(rdbcv1beta1.RdbcConditionDeleting, corev1.ConditionTrue, ReasonDbDeleting, message)
+	return r.updateRdbcStatus(rdbc)
+}
+
+// updateRdbcStatus writes the status through the status subresource,
+// thus it doesn't collide with the spec updates
+func (r *ReconcileRdbc) updateRdbcStatus(rdbc *rdbcv1beta1.Rdbc) error {
+	if err := r.client.Status().Update(context.TODO(), rdbc); err != nil {
+		log.Error(err, "Failed to update CR status")
+		return err
+	}
+	return nil
+}
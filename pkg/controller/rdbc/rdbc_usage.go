@@ -0,0 +1,60 @@
+package rdbc
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// usageIntervalFromEnv returns the interval the db usage is read at, 0 disables the usage stats
+func usageIntervalFromEnv() (time.Duration, error) {
+	value := os.Getenv(usageIntervalEnv)
+	if value == "" {
+		return defaultUsageInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("%s must be a non negative duration, e.g. 1m, got: %s", usageIntervalEnv, value)
+	}
+	return interval, nil
+}
+
+// updateUsage reads the last stats interval of the db into status.usage once the usage is due,
+// the evictions rate is kept to a millionth since the rates below 1 are common,
+// the stats are informational, thus a failed read keeps the previous usage and doesn't fail the reconcile
+func (r *ReconcileRdbc) updateUsage(rdbc *rdbcv1beta1.Rdbc, redisDb *redisenterprise.Bdb, redis redisenterprise.Client) {
+	if r.usageInterval == 0 || r.usageRequeueAfter(rdbc) > 0 {
+		return
+	}
+	stats, err := redis.GetBdbStats(redisDb.Uid)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("failed to read the stats of dbid: %d", redisDb.Uid), "Rdbc.Namespace", rdbc.Namespace, "Rdbc.Name", rdbc.Name)
+		return
+	}
+	now := metav1.Now()
+	rdbc.Status.Usage = &rdbcv1beta1.RdbcUsageStatus{
+		UsedMemory:       int64(math.Round(stats.UsedMemory)),
+		OpsPerSec:        int64(math.Round(stats.OpsPerSec)),
+		ConnectedClients: int64(math.Round(stats.ConnectedClients)),
+		EvictionsPerSec:  *resource.NewScaledQuantity(int64(math.Round(stats.EvictedObjects*1e6)), resource.Micro),
+		LastUpdateTime:   &now,
+	}
+}
+
+// usageRequeueAfter returns the time until the usage is due, 0 if it's due now
+func (r *ReconcileRdbc) usageRequeueAfter(rdbc *rdbcv1beta1.Rdbc) time.Duration {
+	usage := rdbc.Status.Usage
+	if usage == nil || usage.LastUpdateTime == nil {
+		return 0
+	}
+	if left := time.Until(usage.LastUpdateTime.Add(r.usageInterval)); left > 0 {
+		return left
+	}
+	return 0
+}
@@ -0,0 +1,221 @@
+package rdbcbackup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rdbcbackup")
+
+const (
+	// Requeue interval while waiting for the export action
+	exportPollInterval = 10 * time.Second
+
+	// Requeue interval while the Rdbc is not ready
+	rdbcRetryInterval = 30 * time.Second
+)
+
+// Add creates a new RdbcBackup Controller and adds it to the Manager
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRdbcBackup{
+		client:      mgr.GetClient(),
+		scheme:      mgr.GetScheme(),
+		redisClient: redisconfig.SharedClientCache(mgr.GetClient()).GetByName,
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rdbcbackup-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for spec changes of RdbcBackup, the status updates are ignored
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcBackup{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// blank assignment to verify that ReconcileRdbcBackup implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRdbcBackup{}
+
+// ReconcileRdbcBackup exports the Rdbc db to the backup destination and tracks the export to the completion
+type ReconcileRdbcBackup struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// redisClient returns the Redis Enterprise API client of the cluster by name, shared with the Rdbc controller
+	redisClient func(clusterName string) (redisenterprise.Client, error)
+}
+
+func (r *ReconcileRdbcBackup) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling RdbcBackup")
+	backup := &rdbcv1alpha1.RdbcBackup{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, backup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The backup is made once, the completed and the failed backups are never exported again
+	if backup.Status.Phase == rdbcv1alpha1.BackupPhaseCompleted || backup.Status.Phase == rdbcv1alpha1.BackupPhaseFailed {
+		return reconcile.Result{}, nil
+	}
+	if backup.Status.Phase == rdbcv1alpha1.BackupPhaseRunning {
+		return r.trackExport(backup)
+	}
+
+	if errs := rdbcv1alpha1.ValidateRdbcBackupSpec(&backup.Spec, field.NewPath("spec")); len(errs) > 0 {
+		err := errs.ToAggregate()
+		reqLogger.Error(err, "Invalid RdbcBackup spec")
+		return reconcile.Result{}, r.setBackupFailed(backup, err.Error())
+	}
+
+	rdbc := &rdbcv1beta1.Rdbc{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: backup.Spec.RdbcName, Namespace: backup.Namespace}, rdbc)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if errors.IsNotFound(err) || rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseReady || rdbc.Status.DbUid == 0 {
+		message := fmt.Sprintf("waiting for Rdbc %s to be ready", backup.Spec.RdbcName)
+		if errors.IsNotFound(err) {
+			message = fmt.Sprintf("Rdbc %s not found", backup.Spec.RdbcName)
+		}
+		reqLogger.Info(message)
+		if err := r.setBackupPending(backup, message); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: rdbcRetryInterval}, nil
+	}
+
+	location, err := redisconfig.BackupLocation(r.client, backup)
+	if err != nil {
+		reqLogger.Error(err, "Failed to load the backup destination")
+		return reconcile.Result{}, r.setBackupFailed(backup, err.Error())
+	}
+	redis, err := r.redisClient(rdbc.Status.Cluster)
+	if err != nil {
+		reqLogger.Error(err, "Failed to init Redis Enterprise API client")
+		if err := r.setBackupPending(backup, err.Error()); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: rdbcRetryInterval}, nil
+	}
+	action, err := redis.ExportBdb(rdbc.Status.DbUid, location)
+	if err != nil {
+		err = fmt.Errorf("failed to export dbid: %d: %v", rdbc.Status.DbUid, err)
+		reqLogger.Error(err, "Failed to start the export")
+		return reconcile.Result{}, r.setBackupFailed(backup, err.Error())
+	}
+	now := metav1.Now()
+	backup.Status = rdbcv1alpha1.RdbcBackupStatus{
+		Phase:     rdbcv1alpha1.BackupPhaseRunning,
+		Message:   fmt.Sprintf("exporting dbid: %d", rdbc.Status.DbUid),
+		DbUid:     rdbc.Status.DbUid,
+		DbName:    rdbc.Spec.Name,
+		Cluster:   rdbc.Status.Cluster,
+		ActionUid: action.ActionUid,
+		Location:  location.Describe(),
+		StartTime: &now,
+	}
+	reqLogger.Info(fmt.Sprintf("exporting dbid: %d to %s", rdbc.Status.DbUid, backup.Status.Location))
+	if err := r.updateBackupStatus(backup); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: exportPollInterval}, nil
+}
+
+// trackExport polls the export action until it completes or fails
+func (r *ReconcileRdbcBackup) trackExport(backup *rdbcv1alpha1.RdbcBackup) (reconcile.Result, error) {
+	redis, err := r.redisClient(backup.Status.Cluster)
+	if err != nil {
+		log.Error(err, "Failed to init Redis Enterprise API client")
+		return reconcile.Result{RequeueAfter: exportPollInterval}, nil
+	}
+	action, err := redis.GetAction(backup.Status.ActionUid)
+	if err != nil {
+		if redisenterprise.IsNotFound(err) {
+			// The action is purged by the cluster before the completion was observed, the result is unknown
+			return reconcile.Result{}, r.setBackupFailed(backup, fmt.Sprintf("export action %s not found", backup.Status.ActionUid))
+		}
+		log.Error(err, "Failed to get the export action")
+		return reconcile.Result{RequeueAfter: exportPollInterval}, nil
+	}
+	switch action.Status {
+	case redisenterprise.ActionStatusCompleted:
+		now := metav1.Now()
+		backup.Status.Phase = rdbcv1alpha1.BackupPhaseCompleted
+		backup.Status.Message = fmt.Sprintf("exported dbid: %d", backup.Status.DbUid)
+		backup.Status.CompletionTime = &now
+		log.Info(fmt.Sprintf("exported dbid: %d to %s", backup.Status.DbUid, backup.Status.Location))
+		return reconcile.Result{}, r.updateBackupStatus(backup)
+	case redisenterprise.ActionStatusFailed, redisenterprise.ActionStatusCancelled:
+		return reconcile.Result{}, r.setBackupFailed(backup, fmt.Sprintf("export action %s %s: %s", action.ActionUid, action.Status, action.Error))
+	}
+	message := fmt.Sprintf("exporting dbid: %d, progress: %v%%", backup.Status.DbUid, action.Progress)
+	if backup.Status.Message != message {
+		backup.Status.Message = message
+		if err := r.updateBackupStatus(backup); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: exportPollInterval}, nil
+}
+
+func (r *ReconcileRdbcBackup) setBackupPending(backup *rdbcv1alpha1.RdbcBackup, message string) error {
+	if backup.Status.Phase == rdbcv1alpha1.BackupPhasePending && backup.Status.Message == message {
+		return nil
+	}
+	backup.Status.Phase = rdbcv1alpha1.BackupPhasePending
+	backup.Status.Message = message
+	return r.updateBackupStatus(backup)
+}
+
+func (r *ReconcileRdbcBackup) setBackupFailed(backup *rdbcv1alpha1.RdbcBackup, message string) error {
+	backup.Status.Phase = rdbcv1alpha1.BackupPhaseFailed
+	backup.Status.Message = message
+	log.Info(fmt.Sprintf("backup %s/%s failed: %s", backup.Namespace, backup.Name, message))
+	return r.updateBackupStatus(backup)
+}
+
+func (r *ReconcileRdbcBackup) updateBackupStatus(backup *rdbcv1alpha1.RdbcBackup) error {
+	if err := r.client.Status().Update(context.TODO(), backup); err != nil {
+		log.Error(err, "Failed to update RdbcBackup status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package rdbcbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	"github.com/rdbc-operator/pkg/cron"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"github.com/rdbc-operator/pkg/s3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rdbcbackupschedule")
+
+const (
+	// Label of the RdbcBackups with the name of the schedule which created them
+	scheduleLabel = "rdbc.cnative/backup-schedule"
+)
+
+// Add creates a new RdbcBackupSchedule Controller and adds it to the Manager
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRdbcBackupSchedule{client: mgr.GetClient(), scheme: mgr.GetScheme(), s3Client: s3.NewClient}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rdbcbackupschedule-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for spec changes of RdbcBackupSchedule, the status updates are ignored
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcBackupSchedule{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the scheduled RdbcBackups to prune the old backups once the new backups complete
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcBackup{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &rdbcv1alpha1.RdbcBackupSchedule{},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// blank assignment to verify that ReconcileRdbcBackupSchedule implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRdbcBackupSchedule{}
+
+// ReconcileRdbcBackupSchedule creates the RdbcBackups on the cron schedule and prunes the old backups
+type ReconcileRdbcBackupSchedule struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// s3Client returns the client of the S3 storage the pruned exports are deleted from
+	s3Client func(endpoint string, region string, accessKeyId string, secretAccessKey string) s3.Client
+}
+
+func (r *ReconcileRdbcBackupSchedule) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling RdbcBackupSchedule")
+	schedule := &rdbcv1alpha1.RdbcBackupSchedule{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, schedule)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if errs := rdbcv1alpha1.ValidateRdbcBackupScheduleSpec(&schedule.Spec, field.NewPath("spec")); len(errs) > 0 {
+		err := errs.ToAggregate()
+		reqLogger.Error(err, "Invalid RdbcBackupSchedule spec")
+		schedule.Status.Message = err.Error()
+		schedule.Status.NextScheduleTime = nil
+		return reconcile.Result{}, r.updateScheduleStatus(schedule)
+	}
+	cronSchedule, err := cron.Parse(schedule.Spec.Schedule)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	now := time.Now().UTC()
+	if !schedule.Spec.Suspend {
+		if scheduled := lastMissedRun(cronSchedule, schedule, now); !scheduled.IsZero() {
+			if err := r.createBackup(schedule, scheduled); err != nil {
+				reqLogger.Error(err, "Failed to create the scheduled RdbcBackup")
+				return reconcile.Result{}, err
+			}
+			lastScheduleTime := metav1.NewTime(scheduled)
+			schedule.Status.LastScheduleTime = &lastScheduleTime
+			schedule.Status.LastBackup = backupName(schedule, scheduled)
+		}
+	}
+
+	if err := r.pruneBackups(schedule, cronSchedule, now); err != nil {
+		reqLogger.Error(err, "Failed to prune the old RdbcBackups")
+		return reconcile.Result{}, err
+	}
+
+	var result reconcile.Result
+	if schedule.Spec.Suspend {
+		schedule.Status.Message = "suspended"
+		schedule.Status.NextScheduleTime = nil
+	} else if next := cronSchedule.Next(now); next.IsZero() {
+		schedule.Status.Message = fmt.Sprintf("schedule %s never runs", schedule.Spec.Schedule)
+		schedule.Status.NextScheduleTime = nil
+	} else {
+		nextScheduleTime := metav1.NewTime(next)
+		schedule.Status.Message = fmt.Sprintf("next backup at %s", next.Format(time.RFC3339))
+		schedule.Status.NextScheduleTime = &nextScheduleTime
+		result.RequeueAfter = next.Sub(now)
+	}
+	return result, r.updateScheduleStatus(schedule)
+}
+
+// lastMissedRun returns the latest run time of the schedule which is due and has no backup yet,
+// or the zero time if no run is due. The runs missed while the operator was down are made once.
+func lastMissedRun(cronSchedule *cron.Schedule, schedule *rdbcv1alpha1.RdbcBackupSchedule, now time.Time) time.Time {
+	last := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		last = schedule.Status.LastScheduleTime.Time
+	}
+	var missed time.Time
+	for next := cronSchedule.Next(last.UTC()); !next.IsZero() && !next.After(now); next = cronSchedule.Next(next) {
+		missed = next
+	}
+	return missed
+}
+
+// createBackup creates the RdbcBackup of the scheduled run, the backup is owned by the schedule.
+// The backup name is derived from the run time, thus the run is never backed up twice.
+func (r *ReconcileRdbcBackupSchedule) createBackup(schedule *rdbcv1alpha1.RdbcBackupSchedule, scheduled time.Time) error {
+	backup := &rdbcv1alpha1.RdbcBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupName(schedule, scheduled),
+			Namespace: schedule.Namespace,
+			Labels:    map[string]string{scheduleLabel: schedule.Name},
+		},
+		Spec: rdbcv1alpha1.RdbcBackupSpec{
+			RdbcName:    schedule.Spec.RdbcName,
+			Destination: *schedule.Spec.Destination.DeepCopy(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(schedule, backup, r.scheme); err != nil {
+		return err
+	}
+	if err := r.client.Create(context.TODO(), backup); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	log.Info(fmt.Sprintf("created RdbcBackup %s/%s", backup.Namespace, backup.Name))
+	return nil
+}
+
+// pruneBackups deletes the completed backups beyond the retention, newest first,
+// and all the failed backups except the newest one, which is kept for troubleshooting.
+// The pending or running backups which are still unfinished at the next run after their creation
+// are stale and pruned as the failed ones. The S3 exports of the pruned backups are deleted,
+// the mount point exports are kept since the operator has no access to the mounted storage.
+func (r *ReconcileRdbcBackupSchedule) pruneBackups(schedule *rdbcv1alpha1.RdbcBackupSchedule, cronSchedule *cron.Schedule, now time.Time) error {
+	backups := &rdbcv1alpha1.RdbcBackupList{}
+	listOptions := &client.ListOptions{
+		Namespace:     schedule.Namespace,
+		LabelSelector: labels.SelectorFromSet(labels.Set{scheduleLabel: schedule.Name}),
+	}
+	if err := r.client.List(context.TODO(), listOptions, backups); err != nil {
+		return err
+	}
+	sort.Slice(backups.Items, func(i, j int) bool {
+		return backups.Items[j].CreationTimestamp.Before(&backups.Items[i].CreationTimestamp)
+	})
+	retention := schedule.Spec.Retention
+	if retention == 0 {
+		retention = rdbcv1alpha1.DefaultBackupRetention
+	}
+	completed, failed := 0, 0
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		switch backup.Status.Phase {
+		case rdbcv1alpha1.BackupPhaseCompleted:
+			completed++
+			if completed <= retention {
+				continue
+			}
+		case rdbcv1alpha1.BackupPhaseFailed:
+			failed++
+			if failed <= 1 {
+				continue
+			}
+		default:
+			if !isStale(backup, cronSchedule, now) {
+				continue
+			}
+			failed++
+			if failed <= 1 {
+				continue
+			}
+		}
+		if err := r.deleteExport(backup); err != nil {
+			return fmt.Errorf("failed to delete the export of RdbcBackup %s/%s: %v", backup.Namespace, backup.Name, err)
+		}
+		if err := r.client.Delete(context.TODO(), backup); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.Info(fmt.Sprintf("pruned %s RdbcBackup %s/%s", backup.Status.Phase, backup.Namespace, backup.Name))
+	}
+	return nil
+}
+
+// isStale returns true if the pending or running backup is still unfinished at the next run after its creation
+func isStale(backup *rdbcv1alpha1.RdbcBackup, cronSchedule *cron.Schedule, now time.Time) bool {
+	next := cronSchedule.Next(backup.CreationTimestamp.Time.UTC())
+	return !next.IsZero() && !next.After(now)
+}
+
+// deleteExport deletes the S3 objects exported by the backup, the mount point exports are kept
+func (r *ReconcileRdbcBackupSchedule) deleteExport(backup *rdbcv1alpha1.RdbcBackup) error {
+	destination := backup.Spec.Destination.S3
+	if destination == nil {
+		return nil
+	}
+	accessKeyId, secretAccessKey, err := redisconfig.S3Credentials(r.client, backup.Namespace, destination)
+	if err != nil {
+		return err
+	}
+	storage := r.s3Client(destination.Endpoint, destination.Region, accessKeyId, secretAccessKey)
+	keys, err := storage.ListObjects(destination.Bucket, redisconfig.BackupExportPrefix(backup))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := storage.DeleteObject(destination.Bucket, key); err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("deleted %d exported objects of RdbcBackup %s/%s from bucket %s", len(keys), backup.Namespace, backup.Name, destination.Bucket))
+	return nil
+}
+
+// backupName returns the name of the RdbcBackup of the scheduled run, e.g. daily-1571284800
+func backupName(schedule *rdbcv1alpha1.RdbcBackupSchedule, scheduled time.Time) string {
+	return fmt.Sprintf("%s-%d", schedule.Name, scheduled.Unix())
+}
+
+func (r *ReconcileRdbcBackupSchedule) updateScheduleStatus(schedule *rdbcv1alpha1.RdbcBackupSchedule) error {
+	if err := r.client.Status().Update(context.TODO(), schedule); err != nil {
+		log.Error(err, "Failed to update RdbcBackupSchedule status")
+		return err
+	}
+	return nil
+}
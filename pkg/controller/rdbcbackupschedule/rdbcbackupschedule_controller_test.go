@@ -0,0 +1,186 @@
+package rdbcbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rdbc-operator/pkg/apis"
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	"github.com/rdbc-operator/pkg/cron"
+	"github.com/rdbc-operator/pkg/s3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeStorage is an in memory bucket, deleteErr fails the deletions
+type fakeStorage struct {
+	objects   map[string]bool
+	deleteErr error
+}
+
+func (s *fakeStorage) ListObjects(bucket string, prefix string) ([]string, error) {
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, bucket+"/"+prefix) {
+			keys = append(keys, strings.TrimPrefix(key, bucket+"/"))
+		}
+	}
+	return keys, nil
+}
+
+func (s *fakeStorage) DeleteObject(bucket string, key string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	delete(s.objects, bucket+"/"+key)
+	return nil
+}
+
+func TestPruneBackups(t *testing.T) {
+	now := time.Date(2019, 10, 17, 12, 30, 0, 0, time.UTC)
+	// hourly backups, the newest one was created at 12:00
+	backup := func(hoursAgo int, phase rdbcv1alpha1.BackupPhase, destination rdbcv1alpha1.BackupDestination) *rdbcv1alpha1.RdbcBackup {
+		created := now.Add(-30 * time.Minute).Add(-time.Duration(hoursAgo) * time.Hour)
+		return &rdbcv1alpha1.RdbcBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("hourly-%d", created.Unix()),
+				Namespace:         "default",
+				Labels:            map[string]string{scheduleLabel: "hourly"},
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec:   rdbcv1alpha1.RdbcBackupSpec{RdbcName: "cache", Destination: destination},
+			Status: rdbcv1alpha1.RdbcBackupStatus{Phase: phase},
+		}
+	}
+	s3Destination := rdbcv1alpha1.BackupDestination{S3: &rdbcv1alpha1.S3Destination{
+		Bucket:               "backups",
+		Subdir:               "exports",
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "s3"},
+	}}
+	mountPoint := rdbcv1alpha1.BackupDestination{MountPoint: &rdbcv1alpha1.MountPointDestination{Path: "/backups"}}
+	exportOf := func(backup *rdbcv1alpha1.RdbcBackup) string {
+		return fmt.Sprintf("backups/exports/default/%s/cache.rdb.gz", backup.Name)
+	}
+	tests := []struct {
+		name        string
+		backups     []*rdbcv1alpha1.RdbcBackup
+		deleteErr   error
+		wantPruned  []int
+		wantErr     bool
+		wantExports []int
+	}{
+		{
+			name: "completed beyond the retention with the S3 exports",
+			backups: []*rdbcv1alpha1.RdbcBackup{
+				backup(0, rdbcv1alpha1.BackupPhaseCompleted, s3Destination),
+				backup(1, rdbcv1alpha1.BackupPhaseCompleted, s3Destination),
+				backup(2, rdbcv1alpha1.BackupPhaseCompleted, s3Destination),
+			},
+			wantPruned:  []int{2},
+			wantExports: []int{0, 1},
+		},
+		{
+			name: "completed beyond the retention on the mount point",
+			backups: []*rdbcv1alpha1.RdbcBackup{
+				backup(0, rdbcv1alpha1.BackupPhaseCompleted, mountPoint),
+				backup(1, rdbcv1alpha1.BackupPhaseCompleted, mountPoint),
+				backup(2, rdbcv1alpha1.BackupPhaseCompleted, mountPoint),
+			},
+			wantPruned: []int{2},
+		},
+		{
+			name: "stale running backups are pruned as the failed ones",
+			backups: []*rdbcv1alpha1.RdbcBackup{
+				backup(0, rdbcv1alpha1.BackupPhaseRunning, s3Destination),
+				backup(1, rdbcv1alpha1.BackupPhaseRunning, s3Destination),
+				backup(2, rdbcv1alpha1.BackupPhasePending, s3Destination),
+				backup(3, rdbcv1alpha1.BackupPhaseFailed, s3Destination),
+			},
+			wantPruned:  []int{2, 3},
+			wantExports: []int{0, 1},
+		},
+		{
+			name: "failed export deletion keeps the backup",
+			backups: []*rdbcv1alpha1.RdbcBackup{
+				backup(0, rdbcv1alpha1.BackupPhaseCompleted, s3Destination),
+				backup(1, rdbcv1alpha1.BackupPhaseCompleted, s3Destination),
+				backup(2, rdbcv1alpha1.BackupPhaseCompleted, s3Destination),
+			},
+			deleteErr:   fmt.Errorf("access denied"),
+			wantErr:     true,
+			wantExports: []int{0, 1, 2},
+		},
+	}
+	cronSchedule, err := cron.Parse("0 * * * *")
+	if err != nil {
+		t.Fatalf("cron.Parse() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := corev1.AddToScheme(s); err != nil {
+				t.Fatalf("failed to register core/v1: %v", err)
+			}
+			if err := apis.AddToScheme(s); err != nil {
+				t.Fatalf("failed to register the Rdbc APIs: %v", err)
+			}
+			objects := []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "default"},
+				Data:       map[string][]byte{"accessKeyId": []byte("key"), "secretAccessKey": []byte("secret")},
+			}}
+			storage := &fakeStorage{objects: map[string]bool{}, deleteErr: tt.deleteErr}
+			for _, backup := range tt.backups {
+				objects = append(objects, backup)
+				if backup.Spec.Destination.S3 != nil {
+					storage.objects[exportOf(backup)] = true
+				}
+			}
+			r := &ReconcileRdbcBackupSchedule{
+				client: fakeclient.NewFakeClientWithScheme(s, objects...),
+				scheme: s,
+				s3Client: func(endpoint string, region string, accessKeyId string, secretAccessKey string) s3.Client {
+					if accessKeyId != "key" || secretAccessKey != "secret" {
+						t.Errorf("s3Client() credentials = %s/%s, want the credentials Secret", accessKeyId, secretAccessKey)
+					}
+					return storage
+				},
+			}
+			schedule := &rdbcv1alpha1.RdbcBackupSchedule{
+				ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+				Spec:       rdbcv1alpha1.RdbcBackupScheduleSpec{RdbcName: "cache", Schedule: "0 * * * *", Retention: 2},
+			}
+			err := r.pruneBackups(schedule, cronSchedule, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pruneBackups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			pruned := map[int]bool{}
+			for _, i := range tt.wantPruned {
+				pruned[i] = true
+			}
+			for i, backup := range tt.backups {
+				err := r.client.Get(context.TODO(), client.ObjectKey{Name: backup.Name, Namespace: backup.Namespace}, &rdbcv1alpha1.RdbcBackup{})
+				if pruned[i] && !errors.IsNotFound(err) {
+					t.Errorf("backup %d error = %v, want pruned", i, err)
+				}
+				if !pruned[i] && err != nil {
+					t.Errorf("backup %d error = %v, want kept", i, err)
+				}
+			}
+			if len(storage.objects) != len(tt.wantExports) {
+				t.Errorf("exports = %v, want the exports of the backups %v", storage.objects, tt.wantExports)
+			}
+			for _, i := range tt.wantExports {
+				if !storage.objects[exportOf(tt.backups[i])] {
+					t.Errorf("export of backup %d is deleted, want kept", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package rdbcquota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/quota"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rdbcquota")
+
+// Add creates a new RdbcQuota Controller and adds it to the Manager
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRdbcQuota{client: mgr.GetClient()}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rdbcquota-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for spec changes of RdbcQuota, the status updates are ignored
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcQuota{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the Rdbcs, the usage of the quotas in the Rdbc namespace is recomputed on the spec changes
+	err = c.Watch(&source.Kind{Type: &rdbcv1beta1.Rdbc{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			return quotasInNamespace(mgr.GetClient(), a.Meta.GetNamespace())
+		}),
+	}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// blank assignment to verify that ReconcileRdbcQuota implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRdbcQuota{}
+
+// ReconcileRdbcQuota reports the usage of the Rdbcs in the quota namespace,
+// the quotas are enforced by the validating webhook and by the Rdbc controller
+type ReconcileRdbcQuota struct {
+	client client.Client
+}
+
+func (r *ReconcileRdbcQuota) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling RdbcQuota")
+	rdbcQuota := &rdbcv1alpha1.RdbcQuota{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, rdbcQuota)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if errs := rdbcv1alpha1.ValidateRdbcQuotaSpec(&rdbcQuota.Spec, field.NewPath("spec")); len(errs) > 0 {
+		err := errs.ToAggregate()
+		reqLogger.Error(err, "Invalid RdbcQuota spec")
+		rdbcQuota.Status.Message = err.Error()
+		return reconcile.Result{}, r.updateQuotaStatus(rdbcQuota)
+	}
+
+	used, err := quota.Used(context.TODO(), r.client, rdbcQuota.Namespace, "", false)
+	if err != nil {
+		reqLogger.Error(err, "Failed to list Rdbcs")
+		return reconcile.Result{}, err
+	}
+	rdbcQuota.Status.Hard = *rdbcQuota.Spec.Hard.DeepCopy()
+	rdbcQuota.Status.Used = used
+	// The usage exceeds the limits if the quota was lowered or the Rdbcs were created without the webhook
+	if exceeded := quota.Exceeded(&rdbcQuota.Spec.Hard, used); len(exceeded) > 0 {
+		rdbcQuota.Status.Message = fmt.Sprintf("quota exceeded, %s", strings.Join(exceeded, "; "))
+	} else {
+		rdbcQuota.Status.Message = ""
+	}
+	return reconcile.Result{}, r.updateQuotaStatus(rdbcQuota)
+}
+
+// quotasInNamespace returns the RdbcQuotas of the namespace
+func quotasInNamespace(c client.Client, namespace string) []reconcile.Request {
+	quotas := &rdbcv1alpha1.RdbcQuotaList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, quotas); err != nil {
+		log.Error(err, "Failed to list RdbcQuotas")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, q := range quotas.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: q.Name, Namespace: q.Namespace}})
+	}
+	return requests
+}
+
+func (r *ReconcileRdbcQuota) updateQuotaStatus(rdbcQuota *rdbcv1alpha1.RdbcQuota) error {
+	if err := r.client.Status().Update(context.TODO(), rdbcQuota); err != nil {
+		log.Error(err, "Failed to update RdbcQuota status")
+		return err
+	}
+	return nil
+}
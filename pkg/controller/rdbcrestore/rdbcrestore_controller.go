@@ -0,0 +1,297 @@
+package rdbcrestore
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rdbcrestore")
+
+const (
+	// Requeue interval while waiting for the import action
+	importPollInterval = 10 * time.Second
+
+	// Requeue interval while the Rdbc or the backup is not ready
+	rdbcRetryInterval = 30 * time.Second
+)
+
+// RdbcRestore condition reasons
+const (
+	ReasonDbFlushed      = "DbFlushed"
+	ReasonImportComplete = "ImportComplete"
+	ReasonRestoreFailed  = "RestoreFailed"
+)
+
+// Add creates a new RdbcRestore Controller and adds it to the Manager
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRdbcRestore{
+		client:      mgr.GetClient(),
+		scheme:      mgr.GetScheme(),
+		redisClient: redisconfig.SharedClientCache(mgr.GetClient()).GetByName,
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rdbcrestore-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for spec changes of RdbcRestore, the status updates are ignored
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RdbcRestore{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// blank assignment to verify that ReconcileRdbcRestore implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRdbcRestore{}
+
+// ReconcileRdbcRestore imports the RDB files into the Rdbc db and tracks the import to the completion
+type ReconcileRdbcRestore struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// redisClient returns the Redis Enterprise API client of the cluster by name, shared with the Rdbc controller
+	redisClient func(clusterName string) (redisenterprise.Client, error)
+}
+
+func (r *ReconcileRdbcRestore) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling RdbcRestore")
+	restore := &rdbcv1alpha1.RdbcRestore{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, restore)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The restore is made once, the completed and the failed restores are never imported again
+	if restore.Status.Phase == rdbcv1alpha1.RestorePhaseCompleted || restore.Status.Phase == rdbcv1alpha1.RestorePhaseFailed {
+		return reconcile.Result{}, nil
+	}
+	if restore.Status.Phase == rdbcv1alpha1.RestorePhaseRunning {
+		return r.trackImport(restore)
+	}
+
+	if errs := rdbcv1alpha1.ValidateRdbcRestoreSpec(&restore.Spec, field.NewPath("spec")); len(errs) > 0 {
+		err := errs.ToAggregate()
+		reqLogger.Error(err, "Invalid RdbcRestore spec")
+		return reconcile.Result{}, r.setRestoreFailed(restore, err.Error())
+	}
+
+	// The pending spec changes are applied to the db before the import,
+	// the Rdbc controller holds the next changes until the import finishes
+	rdbc := &rdbcv1beta1.Rdbc{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: restore.Spec.RdbcName, Namespace: restore.Namespace}, rdbc)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if errors.IsNotFound(err) {
+		return r.setRestorePending(restore, fmt.Sprintf("Rdbc %s not found", restore.Spec.RdbcName))
+	}
+	if rdbc.Status.Phase != rdbcv1beta1.RdbcPhaseReady || rdbc.Status.ObservedGeneration != rdbc.Generation || rdbc.Status.DbUid == 0 {
+		return r.setRestorePending(restore, fmt.Sprintf("waiting for Rdbc %s to be ready", restore.Spec.RdbcName))
+	}
+
+	location, err := r.restoreLocation(restore)
+	if _, ok := err.(*backupNotReadyError); ok {
+		return r.setRestorePending(restore, err.Error())
+	} else if err != nil {
+		reqLogger.Error(err, "Failed to load the restore location")
+		return reconcile.Result{}, r.setRestoreFailed(restore, err.Error())
+	}
+	redis, err := r.redisClient(rdbc.Status.Cluster)
+	if err != nil {
+		reqLogger.Error(err, "Failed to init Redis Enterprise API client")
+		return r.setRestorePending(restore, err.Error())
+	}
+
+	restore.Status.DbUid = rdbc.Status.DbUid
+	restore.Status.Cluster = rdbc.Status.Cluster
+	restore.Status.Location = location.Describe()
+	if restore.Spec.Flush && !restore.Status.IsConditionTrue(rdbcv1alpha1.RdbcRestoreConditionFlushed) {
+		if err := redis.FlushBdb(rdbc.Status.DbUid); err != nil {
+			err = fmt.Errorf("failed to flush dbid: %d: %v", rdbc.Status.DbUid, err)
+			reqLogger.Error(err, "Failed to flush the db")
+			return reconcile.Result{}, r.setRestoreFailed(restore, err.Error())
+		}
+		message := fmt.Sprintf("flushed dbid: %d", rdbc.Status.DbUid)
+		restore.Status.SetCondition(rdbcv1alpha1.RdbcRestoreConditionFlushed, corev1.ConditionTrue, ReasonDbFlushed, message)
+		reqLogger.Info(message)
+		// The flush is recorded before the import, thus the retried reconcile doesn't flush the db again
+		if err := r.updateRestoreStatus(restore); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	action, err := redis.ImportBdb(rdbc.Status.DbUid, importSources(location, restore.Spec.Files))
+	if err != nil {
+		err = fmt.Errorf("failed to import into dbid: %d: %v", rdbc.Status.DbUid, err)
+		reqLogger.Error(err, "Failed to start the import")
+		return reconcile.Result{}, r.setRestoreFailed(restore, err.Error())
+	}
+	now := metav1.Now()
+	restore.Status.Phase = rdbcv1alpha1.RestorePhaseRunning
+	restore.Status.Message = fmt.Sprintf("importing %s into dbid: %d", strings.Join(restore.Spec.Files, ", "), rdbc.Status.DbUid)
+	restore.Status.ActionUid = action.ActionUid
+	restore.Status.StartTime = &now
+	reqLogger.Info(restore.Status.Message)
+	if err := r.updateRestoreStatus(restore); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: importPollInterval}, nil
+}
+
+// trackImport polls the import action until it completes or fails
+func (r *ReconcileRdbcRestore) trackImport(restore *rdbcv1alpha1.RdbcRestore) (reconcile.Result, error) {
+	redis, err := r.redisClient(restore.Status.Cluster)
+	if err != nil {
+		log.Error(err, "Failed to init Redis Enterprise API client")
+		return reconcile.Result{RequeueAfter: importPollInterval}, nil
+	}
+	action, err := redis.GetAction(restore.Status.ActionUid)
+	if err != nil {
+		if redisenterprise.IsNotFound(err) {
+			// The action is purged by the cluster before the completion was observed, the result is unknown
+			return reconcile.Result{}, r.setRestoreFailed(restore, fmt.Sprintf("import action %s not found", restore.Status.ActionUid))
+		}
+		log.Error(err, "Failed to get the import action")
+		return reconcile.Result{RequeueAfter: importPollInterval}, nil
+	}
+	switch action.Status {
+	case redisenterprise.ActionStatusCompleted:
+		now := metav1.Now()
+		restore.Status.Phase = rdbcv1alpha1.RestorePhaseCompleted
+		restore.Status.Message = fmt.Sprintf("imported into dbid: %d", restore.Status.DbUid)
+		restore.Status.CompletionTime = &now
+		restore.Status.SetCondition(rdbcv1alpha1.RdbcRestoreConditionComplete, corev1.ConditionTrue, ReasonImportComplete, restore.Status.Message)
+		restore.Status.SetCondition(rdbcv1alpha1.RdbcRestoreConditionFailed, corev1.ConditionFalse, ReasonImportComplete, "")
+		log.Info(fmt.Sprintf("imported %s into dbid: %d", restore.Status.Location, restore.Status.DbUid))
+		return reconcile.Result{}, r.updateRestoreStatus(restore)
+	case redisenterprise.ActionStatusFailed, redisenterprise.ActionStatusCancelled:
+		return reconcile.Result{}, r.setRestoreFailed(restore, fmt.Sprintf("import action %s %s: %s", action.ActionUid, action.Status, action.Error))
+	}
+	message := fmt.Sprintf("importing into dbid: %d, progress: %v%%", restore.Status.DbUid, action.Progress)
+	if restore.Status.Message != message {
+		restore.Status.Message = message
+		if err := r.updateRestoreStatus(restore); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: importPollInterval}, nil
+}
+
+// backupNotReadyError is returned while the referenced RdbcBackup is not completed yet
+type backupNotReadyError struct {
+	message string
+}
+
+func (e *backupNotReadyError) Error() string {
+	return e.message
+}
+
+// restoreLocation returns the location of the referenced backup, or of the source
+func (r *ReconcileRdbcRestore) restoreLocation(restore *rdbcv1alpha1.RdbcRestore) (redisenterprise.ExportLocation, error) {
+	if restore.Spec.Source != nil {
+		return redisconfig.DestinationLocation(r.client, restore.Namespace, restore.Spec.Source)
+	}
+	backup := &rdbcv1alpha1.RdbcBackup{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: restore.Spec.BackupRef, Namespace: restore.Namespace}, backup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("RdbcBackup %s not found", restore.Spec.BackupRef)
+		}
+		return nil, err
+	}
+	switch backup.Status.Phase {
+	case rdbcv1alpha1.BackupPhaseCompleted:
+		return redisconfig.BackupLocation(r.client, backup)
+	case rdbcv1alpha1.BackupPhaseFailed:
+		return nil, fmt.Errorf("RdbcBackup %s failed: %s", backup.Name, backup.Status.Message)
+	}
+	return nil, &backupNotReadyError{message: fmt.Sprintf("waiting for RdbcBackup %s to complete", backup.Name)}
+}
+
+// importSources returns the import source of each file in the location
+func importSources(location redisenterprise.ExportLocation, files []string) []redisenterprise.ImportSource {
+	var sources []redisenterprise.ImportSource
+	for _, file := range files {
+		source := redisenterprise.ImportSource{}
+		for key, value := range location {
+			source[key] = value
+		}
+		// The mount point files are set by the full path, the other storages take the file name
+		if location["type"] == "mount_point" {
+			source["path"] = path.Join(fmt.Sprint(location["path"]), file)
+		} else {
+			source["filename"] = file
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// setRestorePending records why the restore waits and requeues it
+func (r *ReconcileRdbcRestore) setRestorePending(restore *rdbcv1alpha1.RdbcRestore, message string) (reconcile.Result, error) {
+	log.Info(fmt.Sprintf("restore %s/%s is pending: %s", restore.Namespace, restore.Name, message))
+	if restore.Status.Phase != rdbcv1alpha1.RestorePhasePending || restore.Status.Message != message {
+		restore.Status.Phase = rdbcv1alpha1.RestorePhasePending
+		restore.Status.Message = message
+		if err := r.updateRestoreStatus(restore); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: rdbcRetryInterval}, nil
+}
+
+func (r *ReconcileRdbcRestore) setRestoreFailed(restore *rdbcv1alpha1.RdbcRestore, message string) error {
+	restore.Status.Phase = rdbcv1alpha1.RestorePhaseFailed
+	restore.Status.Message = message
+	restore.Status.SetCondition(rdbcv1alpha1.RdbcRestoreConditionComplete, corev1.ConditionFalse, ReasonRestoreFailed, "")
+	restore.Status.SetCondition(rdbcv1alpha1.RdbcRestoreConditionFailed, corev1.ConditionTrue, ReasonRestoreFailed, message)
+	log.Info(fmt.Sprintf("restore %s/%s failed: %s", restore.Namespace, restore.Name, message))
+	return r.updateRestoreStatus(restore)
+}
+
+func (r *ReconcileRdbcRestore) updateRestoreStatus(restore *rdbcv1alpha1.RdbcRestore) error {
+	if err := r.client.Status().Update(context.TODO(), restore); err != nil {
+		log.Error(err, "Failed to update RdbcRestore status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package redisenterprisecluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	"github.com/rdbc-operator/pkg/redisconfig"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_redisenterprisecluster")
+
+// probeInterval is the interval of the cluster API probes
+const probeInterval = time.Minute
+
+// Add creates a new RedisEnterpriseCluster Controller and adds it to the Manager
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRedisEnterpriseCluster{
+		client:      mgr.GetClient(),
+		scheme:      mgr.GetScheme(),
+		redisClient: redisconfig.SharedClientCache(mgr.GetClient()).GetByName,
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("redisenterprisecluster-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for spec changes of RedisEnterpriseCluster, the status updates of the probes are ignored
+	err = c.Watch(&source.Kind{Type: &rdbcv1alpha1.RedisEnterpriseCluster{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// blank assignment to verify that ReconcileRedisEnterpriseCluster implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRedisEnterpriseCluster{}
+
+// ReconcileRedisEnterpriseCluster probes the Redis Enterprise cluster API
+// and reports the reachability, the version and the capacity of the cluster
+type ReconcileRedisEnterpriseCluster struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// redisClient returns the Redis Enterprise API client of the cluster by name
+	redisClient func(name string) (redisenterprise.Client, error)
+}
+
+func (r *ReconcileRedisEnterpriseCluster) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Name", request.Name)
+	reqLogger.Info("Reconciling RedisEnterpriseCluster")
+	cluster := &rdbcv1alpha1.RedisEnterpriseCluster{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, cluster)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	status := rdbcv1alpha1.RedisEnterpriseClusterStatus{Reachable: true}
+	if err := r.probe(cluster, &status); err != nil {
+		reqLogger.Error(err, "Redis Enterprise cluster is not reachable")
+		status = rdbcv1alpha1.RedisEnterpriseClusterStatus{
+			Reachable: false,
+			Message:   err.Error(),
+			// Keep the last known version and capacity
+			Version:  cluster.Status.Version,
+			Capacity: cluster.Status.Capacity,
+		}
+	}
+	now := metav1.Now()
+	status.LastProbeTime = &now
+	cluster.Status = status
+	if err := r.client.Status().Update(context.TODO(), cluster); err != nil {
+		reqLogger.Error(err, "Failed to update RedisEnterpriseCluster status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: probeInterval}, nil
+}
+
+// probe checks the cluster API and sets the version and the capacity on the status
+func (r *ReconcileRedisEnterpriseCluster) probe(cluster *rdbcv1alpha1.RedisEnterpriseCluster, status *rdbcv1alpha1.RedisEnterpriseClusterStatus) error {
+	redis, err := r.redisClient(cluster.Name)
+	if err != nil {
+		return err
+	}
+	info, err := redis.GetCluster()
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %v", err)
+	}
+	nodes, err := redis.ListNodes()
+	if err != nil {
+		return fmt.Errorf("failed to list cluster nodes: %v", err)
+	}
+	bdbs, err := redis.ListBdbs()
+	if err != nil {
+		return fmt.Errorf("failed to list cluster dbs: %v", err)
+	}
+	capacity := &rdbcv1alpha1.ClusterCapacity{Nodes: len(nodes)}
+	for _, node := range nodes {
+		capacity.TotalMemory += node.TotalMemory
+		if status.Version == "" {
+			status.Version = node.SoftwareVersion
+		}
+	}
+	for _, bdb := range bdbs {
+		// The replica shards take the same memory as the master shards
+		memory := bdb.MemorySize
+		if redisenterprise.BoolValue(bdb.Replication) {
+			memory *= 2
+		}
+		capacity.ProvisionedMemory += memory
+	}
+	capacity.FreeMemory = capacity.TotalMemory - capacity.ProvisionedMemory
+	// Report the capacity in Megabytes, same as the Rdbc size
+	capacity.TotalMemory = int64(redisenterprise.BytesToMegabytes(capacity.TotalMemory))
+	capacity.ProvisionedMemory = int64(redisenterprise.BytesToMegabytes(capacity.ProvisionedMemory))
+	capacity.FreeMemory = int64(redisenterprise.BytesToMegabytes(capacity.FreeMemory))
+	status.Capacity = capacity
+	status.Message = fmt.Sprintf("cluster %s is reachable", info.Name)
+	return nil
+}
@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "every minute", spec: "* * * * *"},
+		{name: "daily", spec: "0 3 * * *"},
+		{name: "descriptor", spec: "@weekly"},
+		{name: "lists ranges and steps", spec: "0,30 8-18/2 1-15 */3 1-5"},
+		{name: "names", spec: "0 0 * jan-mar mon,wed,fri"},
+		{name: "sunday as 7", spec: "0 0 * * 7"},
+		{name: "surrounding spaces", spec: "  0 3 * * *  "},
+		{name: "too few fields", spec: "0 3 * *", wantErr: true},
+		{name: "too many fields", spec: "0 3 * * * *", wantErr: true},
+		{name: "minute out of range", spec: "60 * * * *", wantErr: true},
+		{name: "hour out of range", spec: "0 24 * * *", wantErr: true},
+		{name: "day of month zero", spec: "0 0 0 * *", wantErr: true},
+		{name: "month out of range", spec: "0 0 1 13 *", wantErr: true},
+		{name: "day of week out of range", spec: "0 0 * * 8", wantErr: true},
+		{name: "reversed range", spec: "0 5-1 * * *", wantErr: true},
+		{name: "zero step", spec: "*/0 * * * *", wantErr: true},
+		{name: "invalid step", spec: "*/x * * * *", wantErr: true},
+		{name: "invalid name", spec: "0 0 * * mon-xyz", wantErr: true},
+		{name: "question mark is not standard", spec: "0 0 ? * 1", wantErr: true},
+		{name: "unknown descriptor", spec: "@often", wantErr: true},
+		{name: "empty", spec: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2019-10-16 is a Wednesday
+	from := time.Date(2019, time.October, 16, 10, 20, 30, 0, time.UTC)
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{name: "every minute", spec: "* * * * *", from: from, want: date(2019, 10, 16, 10, 21)},
+		{name: "exact minute is excluded", spec: "* * * * *", from: date(2019, 10, 16, 10, 20), want: date(2019, 10, 16, 10, 21)},
+		{name: "daily later today", spec: "0 12 * * *", from: from, want: date(2019, 10, 16, 12, 0)},
+		{name: "daily tomorrow", spec: "0 3 * * *", from: from, want: date(2019, 10, 17, 3, 0)},
+		{name: "every 15 minutes", spec: "*/15 * * * *", from: from, want: date(2019, 10, 16, 10, 30)},
+		{name: "start with step", spec: "5/20 * * * *", from: from, want: date(2019, 10, 16, 10, 25)},
+		{name: "hour range with step", spec: "0 8-18/4 * * *", from: from, want: date(2019, 10, 16, 12, 0)},
+		{name: "hourly", spec: "@hourly", from: from, want: date(2019, 10, 16, 11, 0)},
+		{name: "monthly", spec: "@monthly", from: from, want: date(2019, 11, 1, 0, 0)},
+		{name: "yearly", spec: "@yearly", from: from, want: date(2020, 1, 1, 0, 0)},
+		{name: "weekly on sunday", spec: "@weekly", from: from, want: date(2019, 10, 20, 0, 0)},
+		{name: "sunday as 7", spec: "0 0 * * 7", from: from, want: date(2019, 10, 20, 0, 0)},
+		{name: "weekdays skip the weekend", spec: "0 9 * * mon-fri", from: date(2019, 10, 18, 10, 0), want: date(2019, 10, 21, 9, 0)},
+		{name: "month names", spec: "0 0 1 feb *", from: from, want: date(2020, 2, 1, 0, 0)},
+		{name: "end of month", spec: "0 0 31 * *", from: from, want: date(2019, 10, 31, 0, 0)},
+		{name: "leap day", spec: "0 0 29 2 *", from: from, want: date(2020, 2, 29, 0, 0)},
+		{name: "end of year", spec: "59 23 31 12 *", from: from, want: date(2019, 12, 31, 23, 59)},
+		// Both days restricted: either the 1st of the month or a Friday
+		{name: "day of month or day of week", spec: "0 0 1 * 5", from: from, want: date(2019, 10, 18, 0, 0)},
+		{name: "day of month or day of week, month first", spec: "0 0 17 * 1", from: from, want: date(2019, 10, 17, 0, 0)},
+		// A day field starting with "*" is unrestricted: odd days which are Fridays as well
+		{name: "day of month step and day of week", spec: "0 0 */2 * 5", from: from, want: date(2019, 10, 25, 0, 0)},
+		{name: "day of month and day of week step", spec: "0 0 20 * */2", from: from, want: date(2019, 10, 20, 0, 0)},
+		{name: "never runs", spec: "0 0 30 2 *", from: from, want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.spec, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) of %q = %v, want %v", tt.from, tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextSequence(t *testing.T) {
+	s, err := Parse("30 2 * * 1,4")
+	if err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+	want := []time.Time{
+		date(2019, 10, 17, 2, 30),
+		date(2019, 10, 21, 2, 30),
+		date(2019, 10, 24, 2, 30),
+		date(2019, 10, 28, 2, 30),
+	}
+	next := date(2019, 10, 16, 0, 0)
+	for _, w := range want {
+		next = s.Next(next)
+		if !next.Equal(w) {
+			t.Fatalf("Next = %v, want %v", next, w)
+		}
+	}
+}
+
+func date(year int, month time.Month, day int, hour int, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
@@ -0,0 +1,176 @@
+// Package metrics defines the operator Prometheus metrics, the metrics are registered in the controller-runtime
+// registry and are served on the manager metrics endpoint next to the controller metrics
+package metrics
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("metrics")
+
+// prefix of the metric names
+const prefix = "rdbc"
+
+var (
+	redisAPIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prefix,
+		Name:      "redis_api_requests_total",
+		Help:      "Number of the Redis Enterprise API requests by endpoint and response status code, the code is empty for the failed requests",
+	}, []string{"method", "endpoint", "code"})
+
+	redisAPIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prefix,
+		Name:      "redis_api_errors_total",
+		Help:      "Number of the failed and the non 2xx Redis Enterprise API requests by endpoint and response status code",
+	}, []string{"method", "endpoint", "code"})
+
+	redisAPIDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: prefix,
+		Name:      "redis_api_request_duration_seconds",
+		Help:      "Latency of the Redis Enterprise API requests by endpoint",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+
+	provisioningFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prefix,
+		Name:      "provisioning_failures_total",
+		Help:      "Number of the failed db creations by Rdbc namespace and reason",
+	}, []string{"namespace", "reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(redisAPIRequests, redisAPIErrors, redisAPIDuration, provisioningFailures)
+}
+
+// The uids in the API paths are replaced, thus the endpoint label has a bounded number of values
+var (
+	numericSegment = regexp.MustCompile(`/[0-9]+(/|$)`)
+	actionUid      = regexp.MustCompile(`^/v1/actions/[^/]+`)
+)
+
+// endpoint returns the API path with the uids replaced by :uid, e.g. /v1/bdbs/:uid/actions/export
+func endpoint(path string) string {
+	path = actionUid.ReplaceAllString(path, "/v1/actions/:uid")
+	// The adjacent numeric segments share the separator, thus the replacement runs until no segment is left
+	for numericSegment.MatchString(path) {
+		path = numericSegment.ReplaceAllString(path, "/:uid$1")
+	}
+	return path
+}
+
+// ObserveRedisAPIRequest records the Redis Enterprise API request, statusCode is 0 if the request failed without a response
+func ObserveRedisAPIRequest(method string, path string, statusCode int, duration time.Duration) {
+	ep := endpoint(path)
+	code := ""
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	redisAPIRequests.WithLabelValues(method, ep, code).Inc()
+	redisAPIDuration.WithLabelValues(method, ep).Observe(duration.Seconds())
+	if statusCode == 0 || statusCode > 299 {
+		redisAPIErrors.WithLabelValues(method, ep, code).Inc()
+	}
+}
+
+// IncProvisioningFailures counts the failed db creation of the Rdbc namespace
+func IncProvisioningFailures(namespace string, reason string) {
+	provisioningFailures.WithLabelValues(namespace, reason).Inc()
+}
+
+var (
+	managedDbsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(prefix, "", "managed_dbs"),
+		"Number of the dbs managed by the Rdbcs by Rdbc namespace and RedisEnterpriseCluster, the cluster is empty for the operator default cluster",
+		[]string{"namespace", "cluster"}, nil)
+	managedMemoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(prefix, "", "managed_db_memory_bytes"),
+		"Memory limit of the dbs managed by the Rdbcs by Rdbc namespace and RedisEnterpriseCluster",
+		[]string{"namespace", "cluster"}, nil)
+
+	// The db usage gauges follow status.usage of the Rdbcs
+	usedMemoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(prefix, "db", "used_memory_bytes"),
+		"Memory used by the db of the Rdbc",
+		[]string{"namespace", "name"}, nil)
+	opsPerSecDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(prefix, "db", "ops_per_second"),
+		"Operations per second of the db of the Rdbc",
+		[]string{"namespace", "name"}, nil)
+	connectedClientsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(prefix, "db", "connected_clients"),
+		"Number of the clients connected to the db of the Rdbc",
+		[]string{"namespace", "name"}, nil)
+	evictionsPerSecDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(prefix, "db", "evictions_per_second"),
+		"Keys evicted per second from the db of the Rdbc",
+		[]string{"namespace", "name"}, nil)
+)
+
+// rdbcCollector computes the managed dbs and the db usage gauges from the cached Rdbcs on every scrape,
+// thus the gauges of the deleted Rdbcs are never left behind
+type rdbcCollector struct {
+	client client.Client
+}
+
+// RegisterRdbcCollector registers the managed dbs and the db usage gauges, c should read from the manager cache
+func RegisterRdbcCollector(c client.Client) error {
+	return metrics.Registry.Register(&rdbcCollector{client: c})
+}
+
+func (c *rdbcCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedDbsDesc
+	ch <- managedMemoryDesc
+	ch <- usedMemoryDesc
+	ch <- opsPerSecDesc
+	ch <- connectedClientsDesc
+	ch <- evictionsPerSecDesc
+}
+
+func (c *rdbcCollector) Collect(ch chan<- prometheus.Metric) {
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := c.client.List(context.TODO(), &client.ListOptions{}, rdbcs); err != nil {
+		log.Error(err, "Failed to list Rdbcs")
+		return
+	}
+	type key struct{ namespace, cluster string }
+	dbs := map[key]int{}
+	memory := map[key]int64{}
+	for _, rdbc := range rdbcs.Items {
+		// The Rdbcs without a db manage nothing yet
+		if rdbc.Status.DbUid == 0 {
+			continue
+		}
+		k := key{rdbc.Namespace, rdbc.Status.Cluster}
+		dbs[k]++
+		memory[k] += int64(rdbc.Status.MemorySize) * 1024 * 1024
+		if usage := rdbc.Status.Usage; usage != nil {
+			ch <- prometheus.MustNewConstMetric(usedMemoryDesc, prometheus.GaugeValue, float64(usage.UsedMemory), rdbc.Namespace, rdbc.Name)
+			ch <- prometheus.MustNewConstMetric(opsPerSecDesc, prometheus.GaugeValue, float64(usage.OpsPerSec), rdbc.Namespace, rdbc.Name)
+			ch <- prometheus.MustNewConstMetric(connectedClientsDesc, prometheus.GaugeValue, float64(usage.ConnectedClients), rdbc.Namespace, rdbc.Name)
+			ch <- prometheus.MustNewConstMetric(evictionsPerSecDesc, prometheus.GaugeValue, quantityToFloat(usage.EvictionsPerSec), rdbc.Namespace, rdbc.Name)
+		}
+	}
+	for k, count := range dbs {
+		ch <- prometheus.MustNewConstMetric(managedDbsDesc, prometheus.GaugeValue, float64(count), k.namespace, k.cluster)
+		ch <- prometheus.MustNewConstMetric(managedMemoryDesc, prometheus.GaugeValue, float64(memory[k]), k.namespace, k.cluster)
+	}
+}
+
+// quantityToFloat returns the exact value of the decimal quantity, e.g. 0.25 for 250m
+func quantityToFloat(q resource.Quantity) float64 {
+	value, err := strconv.ParseFloat(q.AsDec().String(), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
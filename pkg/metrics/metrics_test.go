@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestQuantityToFloat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{in: "0", want: 0},
+		{in: "250m", want: 0.25},
+		{in: "333333u", want: 0.333333},
+		{in: "12", want: 12},
+		{in: "1500", want: 1500},
+	}
+	for _, tt := range tests {
+		if got := quantityToFloat(resource.MustParse(tt.in)); got != tt.want {
+			t.Errorf("quantityToFloat(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Package passwordgen generates the db passwords from crypto/rand,
+// by the operator wide policy set by the env vars and the per Rdbc policy
+package passwordgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// Length of the generated passwords, operator wide
+	PasswordLength = "PASSWORD_LENGTH"
+
+	// Charset of the generated passwords, operator wide
+	PasswordCharset = "PASSWORD_CHARSET"
+
+	// DefaultLength is the length of the generated passwords if not set
+	DefaultLength = 24
+
+	// DefaultCharset is the charset of the generated passwords if not set
+	DefaultCharset = rdbcv1beta1.PasswordCharsetAlphanumeric
+)
+
+// Character classes, every generated password contains at least a single character of each class of the charset
+const (
+	lowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	upperLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits       = "0123456789"
+	// The symbols which need no escaping in the redis:// URLs and in the shell
+	symbols = "-_.~+=^*!"
+)
+
+var charsets = map[string][]string{
+	rdbcv1beta1.PasswordCharsetAlphanumeric:        {lowerLetters, upperLetters, digits},
+	rdbcv1beta1.PasswordCharsetAlphanumericSymbols: {lowerLetters, upperLetters, digits, symbols},
+}
+
+// FromEnv returns the operator wide policy, the unset env vars default to
+// DefaultLength and DefaultCharset
+func FromEnv() (rdbcv1beta1.PasswordPolicy, error) {
+	policy := rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: DefaultCharset}
+	if length, found := os.LookupEnv(PasswordLength); found && length != "" {
+		l, err := strconv.Atoi(length)
+		if err != nil {
+			return policy, fmt.Errorf("%s must be a number: %v", PasswordLength, err)
+		}
+		policy.Length = l
+	}
+	if charset, found := os.LookupEnv(PasswordCharset); found && charset != "" {
+		policy.Charset = charset
+	}
+	if errs := rdbcv1beta1.ValidatePasswordPolicy(&policy, field.NewPath("env")); len(errs) > 0 {
+		return policy, fmt.Errorf("invalid %s or %s: %v", PasswordLength, PasswordCharset, errs.ToAggregate())
+	}
+	return policy, nil
+}
+
+// Merge returns the policy with the fields set by the override
+func Merge(policy rdbcv1beta1.PasswordPolicy, override *rdbcv1beta1.PasswordPolicy) rdbcv1beta1.PasswordPolicy {
+	if override == nil {
+		return policy
+	}
+	if override.Length != 0 {
+		policy.Length = override.Length
+	}
+	if override.Charset != "" {
+		policy.Charset = override.Charset
+	}
+	return policy
+}
+
+// Generate returns a new password of the policy length and charset.
+// The characters are picked uniformly from the charset,
+// the passwords which miss any character class are dropped and generated again.
+func Generate(policy rdbcv1beta1.PasswordPolicy) (string, error) {
+	classes, ok := charsets[policy.Charset]
+	if !ok {
+		return "", fmt.Errorf("unknown password charset: %s", policy.Charset)
+	}
+	if policy.Length < len(classes) {
+		return "", fmt.Errorf("password length %d is too short for charset: %s", policy.Length, policy.Charset)
+	}
+	alphabet := strings.Join(classes, "")
+	max := big.NewInt(int64(len(alphabet)))
+	password := make([]byte, policy.Length)
+	for {
+		for i := range password {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			password[i] = alphabet[n.Int64()]
+		}
+		if containsAllClasses(string(password), classes) {
+			return string(password), nil
+		}
+	}
+}
+
+func containsAllClasses(password string, classes []string) bool {
+	for _, class := range classes {
+		if !strings.ContainsAny(password, class) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,211 @@
+package passwordgen
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  rdbcv1beta1.PasswordPolicy
+		classes []string
+	}{
+		{
+			name:    "default policy",
+			policy:  rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: DefaultCharset},
+			classes: []string{lowerLetters, upperLetters, digits},
+		},
+		{
+			name:    "min length alphanumeric",
+			policy:  rdbcv1beta1.PasswordPolicy{Length: rdbcv1beta1.MinPasswordLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumeric},
+			classes: []string{lowerLetters, upperLetters, digits},
+		},
+		{
+			name:    "max length alphanumeric",
+			policy:  rdbcv1beta1.PasswordPolicy{Length: rdbcv1beta1.MaxPasswordLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumeric},
+			classes: []string{lowerLetters, upperLetters, digits},
+		},
+		{
+			name:    "min length with symbols",
+			policy:  rdbcv1beta1.PasswordPolicy{Length: rdbcv1beta1.MinPasswordLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols},
+			classes: []string{lowerLetters, upperLetters, digits, symbols},
+		},
+		{
+			name:    "max length with symbols",
+			policy:  rdbcv1beta1.PasswordPolicy{Length: rdbcv1beta1.MaxPasswordLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols},
+			classes: []string{lowerLetters, upperLetters, digits, symbols},
+		},
+		{
+			// Every class must fit, the shortest password is a single character of each class
+			name:    "a character per class",
+			policy:  rdbcv1beta1.PasswordPolicy{Length: 4, Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols},
+			classes: []string{lowerLetters, upperLetters, digits, symbols},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alphabet := strings.Join(tt.classes, "")
+			for i := 0; i < 200; i++ {
+				password, err := Generate(tt.policy)
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				if len(password) != tt.policy.Length {
+					t.Fatalf("len(%q) = %d, want %d", password, len(password), tt.policy.Length)
+				}
+				for _, c := range password {
+					if !strings.ContainsRune(alphabet, c) {
+						t.Fatalf("%q contains %q out of the %s charset", password, c, tt.policy.Charset)
+					}
+				}
+				for _, class := range tt.classes {
+					if !strings.ContainsAny(password, class) {
+						t.Fatalf("%q contains no character of %q", password, class)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy rdbcv1beta1.PasswordPolicy
+	}{
+		{name: "unknown charset", policy: rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: "hex"}},
+		{name: "empty charset", policy: rdbcv1beta1.PasswordPolicy{Length: DefaultLength}},
+		{name: "shorter than the classes", policy: rdbcv1beta1.PasswordPolicy{Length: 3, Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols}},
+		{name: "zero length", policy: rdbcv1beta1.PasswordPolicy{Charset: rdbcv1beta1.PasswordCharsetAlphanumeric}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if password, err := Generate(tt.policy); err == nil {
+				t.Errorf("Generate() = %q, want error", password)
+			}
+		})
+	}
+}
+
+func TestGenerateNoRepetition(t *testing.T) {
+	policy := rdbcv1beta1.PasswordPolicy{Length: rdbcv1beta1.MinPasswordLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumeric}
+	seen := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		password, err := Generate(policy)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if seen[password] {
+			t.Fatalf("password %q generated twice in %d draws", password, i+1)
+		}
+		seen[password] = true
+	}
+}
+
+// TestGenerateEntropy checks the characters are picked uniformly from the charset,
+// the dropped passwords which miss a class bias the distribution slightly only
+func TestGenerateEntropy(t *testing.T) {
+	for charset, classes := range charsets {
+		t.Run(charset, func(t *testing.T) {
+			alphabet := strings.Join(classes, "")
+			policy := rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: charset}
+			counts := map[rune]int{}
+			total := 0
+			for i := 0; i < 2000; i++ {
+				password, err := Generate(policy)
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				for _, c := range password {
+					counts[c]++
+					total++
+				}
+			}
+			expected := float64(total) / float64(len(alphabet))
+			entropy := 0.0
+			for _, c := range alphabet {
+				count := float64(counts[c])
+				if count < expected*0.7 || count > expected*1.3 {
+					t.Errorf("%q drawn %v times, expected about %.0f", c, count, expected)
+				}
+				if count > 0 {
+					p := count / float64(total)
+					entropy -= p * math.Log2(p)
+				}
+			}
+			// The bits per character of the uniform pick
+			if max := math.Log2(float64(len(alphabet))); entropy < max*0.99 {
+				t.Errorf("entropy = %.3f bits per character, want at least 99%% of %.3f", entropy, max)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		length  string
+		charset string
+		want    rdbcv1beta1.PasswordPolicy
+		wantErr bool
+	}{
+		{name: "defaults", want: rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: DefaultCharset}},
+		{name: "length", length: "32", want: rdbcv1beta1.PasswordPolicy{Length: 32, Charset: DefaultCharset}},
+		{
+			name:    "charset",
+			charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols,
+			want:    rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols},
+		},
+		{name: "min length", length: "16", want: rdbcv1beta1.PasswordPolicy{Length: 16, Charset: DefaultCharset}},
+		{name: "max length", length: "128", want: rdbcv1beta1.PasswordPolicy{Length: 128, Charset: DefaultCharset}},
+		{name: "too short", length: "15", wantErr: true},
+		{name: "too long", length: "129", wantErr: true},
+		{name: "not a number", length: "long", wantErr: true},
+		{name: "unknown charset", charset: "hex", wantErr: true},
+	}
+	defer os.Unsetenv(PasswordLength)
+	defer os.Unsetenv(PasswordCharset)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(PasswordLength, tt.length)
+			os.Setenv(PasswordCharset, tt.charset)
+			got, err := FromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("FromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	policy := rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: DefaultCharset}
+	tests := []struct {
+		name     string
+		override *rdbcv1beta1.PasswordPolicy
+		want     rdbcv1beta1.PasswordPolicy
+	}{
+		{name: "no override", want: policy},
+		{name: "empty override", override: &rdbcv1beta1.PasswordPolicy{}, want: policy},
+		{name: "length", override: &rdbcv1beta1.PasswordPolicy{Length: 64}, want: rdbcv1beta1.PasswordPolicy{Length: 64, Charset: DefaultCharset}},
+		{
+			name:     "charset",
+			override: &rdbcv1beta1.PasswordPolicy{Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols},
+			want:     rdbcv1beta1.PasswordPolicy{Length: DefaultLength, Charset: rdbcv1beta1.PasswordCharsetAlphanumericSymbols},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Merge(policy, tt.override); got != tt.want {
+				t.Errorf("Merge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package quota computes the RdbcQuota usage of the Rdbcs and checks the requested dbs against the quotas
+// of their namespace, the quotas are enforced by the validating webhook and by the Rdbc controller
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Usage returns the quota usage of the db of the defaulted spec
+func Usage(spec *rdbcv1beta1.RdbcSpec) rdbcv1alpha1.RdbcQuotaUsage {
+	usage := rdbcv1alpha1.RdbcQuotaUsage{Memory: spec.Size, Dbs: 1, Shards: spec.ShardsCount}
+	if spec.Replication {
+		usage.Memory *= 2
+		usage.ReplicatedDbs = 1
+	}
+	return usage
+}
+
+// Add returns the sum of the usages
+func Add(a, b rdbcv1alpha1.RdbcQuotaUsage) rdbcv1alpha1.RdbcQuotaUsage {
+	return rdbcv1alpha1.RdbcQuotaUsage{
+		Memory:        a.Memory + b.Memory,
+		Dbs:           a.Dbs + b.Dbs,
+		Shards:        a.Shards + b.Shards,
+		ReplicatedDbs: a.ReplicatedDbs + b.ReplicatedDbs,
+	}
+}
+
+// Used returns the usage of the Rdbcs in the namespace, the Rdbc named exclude is not counted.
+// If provisioned is set, only the Rdbcs with a db are counted.
+func Used(ctx context.Context, c client.Client, namespace string, exclude string, provisioned bool) (rdbcv1alpha1.RdbcQuotaUsage, error) {
+	used := rdbcv1alpha1.RdbcQuotaUsage{}
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := c.List(ctx, &client.ListOptions{Namespace: namespace}, rdbcs); err != nil {
+		return used, err
+	}
+	for i := range rdbcs.Items {
+		rdbc := &rdbcs.Items[i]
+		if rdbc.Name == exclude || (provisioned && rdbc.Status.DbUid == 0) {
+			continue
+		}
+		spec := rdbc.Spec.DeepCopy()
+		rdbcv1beta1.SetDefaults_RdbcSpec(spec)
+		used = Add(used, Usage(spec))
+	}
+	return used, nil
+}
+
+// Exceeded returns the messages of the limits the usage exceeds, e.g. "memory: used 5120, limited to 4096"
+func Exceeded(hard *rdbcv1alpha1.RdbcQuotaLimits, used rdbcv1alpha1.RdbcQuotaUsage) []string {
+	var exceeded []string
+	for _, l := range limits(hard, used) {
+		if l.hard != nil && l.used > *l.hard {
+			exceeded = append(exceeded, fmt.Sprintf("%s: used %d, limited to %d", l.name, l.used, *l.hard))
+		}
+	}
+	return exceeded
+}
+
+// Check returns the messages of the quota limits the requested usage of the Rdbc exceeds,
+// empty if the Rdbc fits all the quotas of its namespace. As with ResourceQuota, a limit is violated
+// only if the requested usage exceeds the current usage of the Rdbc, thus the dbs which don't grow
+// are not blocked by a lowered quota. If provisioned is set, only the other Rdbcs with a db are counted.
+func Check(ctx context.Context, c client.Client, rdbc *rdbcv1beta1.Rdbc, requested, current rdbcv1alpha1.RdbcQuotaUsage, provisioned bool) ([]string, error) {
+	quotas := &rdbcv1alpha1.RdbcQuotaList{}
+	if err := c.List(ctx, &client.ListOptions{Namespace: rdbc.Namespace}, quotas); err != nil {
+		return nil, err
+	}
+	if len(quotas.Items) == 0 {
+		return nil, nil
+	}
+	used, err := Used(ctx, c, rdbc.Namespace, rdbc.Name, provisioned)
+	if err != nil {
+		return nil, err
+	}
+	total := Add(used, requested)
+	var exceeded []string
+	for _, quota := range quotas.Items {
+		for _, l := range limits(&quota.Spec.Hard, total) {
+			if l.hard == nil || l.used <= *l.hard || l.of(requested) <= l.of(current) {
+				continue
+			}
+			exceeded = append(exceeded, fmt.Sprintf("exceeds RdbcQuota %s, %s: requested %d, used %d, limited to %d",
+				quota.Name, l.name, l.of(requested), l.used-l.of(requested), *l.hard))
+		}
+	}
+	return exceeded, nil
+}
+
+// limit is a quota limit with its usage
+type limit struct {
+	name string
+	hard *int
+	used int
+	// of returns the limited resource of the usage
+	of func(usage rdbcv1alpha1.RdbcQuotaUsage) int
+}
+
+func limits(hard *rdbcv1alpha1.RdbcQuotaLimits, used rdbcv1alpha1.RdbcQuotaUsage) []limit {
+	return []limit{
+		{"memory", hard.Memory, used.Memory, func(u rdbcv1alpha1.RdbcQuotaUsage) int { return u.Memory }},
+		{"dbs", hard.Dbs, used.Dbs, func(u rdbcv1alpha1.RdbcQuotaUsage) int { return u.Dbs }},
+		{"shards", hard.Shards, used.Shards, func(u rdbcv1alpha1.RdbcQuotaUsage) int { return u.Shards }},
+		{"replicatedDbs", hard.ReplicatedDbs, used.ReplicatedDbs, func(u rdbcv1alpha1.RdbcQuotaUsage) int { return u.ReplicatedDbs }},
+	}
+}
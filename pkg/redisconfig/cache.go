@@ -0,0 +1,151 @@
+package redisconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClientCache keeps a Redis Enterprise API client per cluster,
+// thus the credentials and the certificates are loaded once instead of on every reconcile.
+// The clients are dropped when the cluster spec changes or when a referenced Secret or ConfigMap changes.
+type ClientCache struct {
+	client  client.Client
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	redis redisenterprise.Client
+	// generation of the RedisEnterpriseCluster the client was loaded from
+	generation int64
+	// refs are the Secrets and ConfigMaps the configurations are loaded from
+	refs []types.NamespacedName
+}
+
+// NewClientCache returns an empty cache which loads the configurations with the client
+func NewClientCache(c client.Client) *ClientCache {
+	return &ClientCache{client: c, entries: map[string]*cacheEntry{}}
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   *ClientCache
+)
+
+// SharedClientCache returns the cache shared by the controllers of the process, created with the client
+// on the first call, thus the clients are loaded once and are invalidated for all the controllers
+func SharedClientCache(c client.Client) *ClientCache {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	if shared == nil {
+		shared = NewClientCache(c)
+	}
+	return shared
+}
+
+// Get returns the API client of the RedisEnterpriseCluster,
+// or of the cluster set by the operator env vars if the cluster is nil.
+// The configurations are loaded on the first call and after the client was invalidated.
+func (c *ClientCache) Get(cluster *rdbcv1alpha1.RedisEnterpriseCluster) (redisenterprise.Client, error) {
+	key := ""
+	var generation int64
+	if cluster != nil {
+		key = cluster.Name
+		generation = cluster.Generation
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok && entry.redis != nil && entry.generation == generation {
+		return entry.redis, nil
+	}
+	// Keep the refs of the failed loads as well, thus fixing the Secret triggers a retry
+	entry = &cacheEntry{generation: generation, refs: configRefs(cluster)}
+	c.entries[key] = entry
+	var redisConfig *RedisConfig
+	var err error
+	if cluster == nil {
+		redisConfig, err = FromEnv(c.client)
+	} else {
+		redisConfig, err = FromCluster(c.client, cluster)
+	}
+	if err != nil {
+		return nil, err
+	}
+	redis, err := redisConfig.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	entry.redis = redis
+	log.Info("Loaded Redis Enterprise API configurations", "Cluster", key, "APIUrl", redisConfig.APIUrl)
+	return redis, nil
+}
+
+// GetByName returns the API client of the RedisEnterpriseCluster by name,
+// or of the cluster set by the operator env vars if the name is empty
+func (c *ClientCache) GetByName(name string) (redisenterprise.Client, error) {
+	if name == "" {
+		return c.Get(nil)
+	}
+	cluster := &rdbcv1alpha1.RedisEnterpriseCluster{}
+	if err := c.client.Get(context.TODO(), client.ObjectKey{Name: name}, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get RedisEnterpriseCluster: %v: %v", name, err)
+	}
+	return c.Get(cluster)
+}
+
+// InvalidateRef drops the clients loaded from the Secret or the ConfigMap,
+// returns true if any cluster configurations reference it
+func (c *ClientCache) InvalidateRef(namespace string, name string) bool {
+	ref := types.NamespacedName{Namespace: namespace, Name: name}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	referenced := false
+	for key, entry := range c.entries {
+		for _, r := range entry.refs {
+			if r == ref {
+				referenced = true
+				if entry.redis != nil {
+					log.Info("Redis Enterprise API configurations changed, reloading", "Cluster", key, "Ref", ref.String())
+				}
+				entry.redis = nil
+				break
+			}
+		}
+	}
+	return referenced
+}
+
+// configRefs returns the Secrets and ConfigMaps the cluster configurations are loaded from
+func configRefs(cluster *rdbcv1alpha1.RedisEnterpriseCluster) []types.NamespacedName {
+	var refs []types.NamespacedName
+	if cluster == nil {
+		namespace := os.Getenv(RedisNS)
+		for _, env := range []string{RedisCredSecret, RedisCASecret, RedisCAConfigMap, RedisClientCertSecret} {
+			if name := os.Getenv(env); name != "" {
+				refs = append(refs, types.NamespacedName{Namespace: namespace, Name: name})
+			}
+		}
+		return refs
+	}
+	refs = append(refs, types.NamespacedName{Namespace: cluster.Spec.CredentialsSecretRef.Namespace, Name: cluster.Spec.CredentialsSecretRef.Name})
+	if tls := cluster.Spec.TLS; tls != nil {
+		if tls.CASecretRef != nil {
+			refs = append(refs, types.NamespacedName{Namespace: tls.CASecretRef.Namespace, Name: tls.CASecretRef.Name})
+		}
+		if tls.CAConfigMapRef != nil {
+			refs = append(refs, types.NamespacedName{Namespace: tls.CAConfigMapRef.Namespace, Name: tls.CAConfigMapRef.Name})
+		}
+		if tls.ClientCertSecretRef != nil {
+			refs = append(refs, types.NamespacedName{Namespace: tls.ClientCertSecretRef.Namespace, Name: tls.ClientCertSecretRef.Name})
+		}
+	}
+	return refs
+}
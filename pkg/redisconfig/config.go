@@ -0,0 +1,390 @@
+// Package redisconfig loads the Redis Enterprise API configurations,
+// either from a RedisEnterpriseCluster resource or from the operator env vars
+package redisconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	"github.com/rdbc-operator/pkg/redisenterprise"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("redisconfig")
+
+const (
+	// Namespace where redis deployed
+	RedisNS = "REDIS_NS"
+
+	// Redis Enterprise secret name which contains redis admin username and password
+	RedisCredSecret = "REDIS_CRED_SECRET"
+
+	// Redis Service name for API access
+	RedisAPI = "REDIS_API"
+
+	// Secret in Redis namespace which contains the CA bundle (ca.crt) of the Redis API certificate
+	RedisCASecret = "REDIS_CA_SECRET"
+
+	// ConfigMap in Redis namespace which contains the CA bundle (ca.crt), used if the CA Secret is not set
+	RedisCAConfigMap = "REDIS_CA_CONFIGMAP"
+
+	// kubernetes.io/tls Secret in Redis namespace with the client certificate for mTLS with the Redis API
+	RedisClientCertSecret = "REDIS_CLIENT_CERT_SECRET"
+
+	// Set to "true" to skip the Redis API certificate verification, not secure
+	RedisInsecureSkipVerify = "REDIS_INSECURE_SKIP_VERIFY"
+
+	// Secret in Redis namespace which contains the export location JSON (location) of the db exports
+	RedisExportLocationSecret = "REDIS_EXPORT_LOCATION_SECRET"
+
+	// Time limit of a single Redis API request of all the clusters, e.g. 30s
+	RedisAPITimeout = "REDIS_API_TIMEOUT"
+
+	// Key of the CA bundle in the CA Secret or ConfigMap
+	caCertKey = "ca.crt"
+
+	// Key of the Redis Enterprise export location JSON in the export location Secret
+	exportLocationKey = "location"
+
+	// Keys of the S3 credentials in the backup destination credentials Secret
+	accessKeyIdKey     = "accessKeyId"
+	secretAccessKeyKey = "secretAccessKey"
+)
+
+// RedisConfig is the Redis Enterprise API access of a single cluster
+type RedisConfig struct {
+	// ClusterName is the RedisEnterpriseCluster name, empty for the env vars configurations
+	ClusterName string
+	Username    string
+	Password    string
+	APIUrl      string
+	Namespace   string
+	CredSecret  string
+	TLS         *redisenterprise.TLSOptions
+	// Timeout is the time limit of a single API request
+	Timeout time.Duration
+}
+
+// NewClient returns Redis Enterprise API client for the configurations
+func (c *RedisConfig) NewClient() (redisenterprise.Client, error) {
+	tlsConfig, err := redisenterprise.NewTLSConfig(c.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init Redis API TLS configs: %v", err)
+	}
+	return redisenterprise.NewClient(c.APIUrl, c.Username, c.Password, tlsConfig, c.Timeout), nil
+}
+
+// FromCluster loads the configurations of the RedisEnterpriseCluster,
+// the credentials and the certificates are read from the referenced Secrets and ConfigMap
+func FromCluster(c client.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster) (*RedisConfig, error) {
+	redisConfig := &RedisConfig{
+		ClusterName: cluster.Name,
+		APIUrl:      cluster.Spec.APIUrl,
+		Namespace:   cluster.Spec.CredentialsSecretRef.Namespace,
+		CredSecret:  cluster.Spec.CredentialsSecretRef.Name,
+	}
+	timeout, err := GetRedisAPITimeout()
+	if err != nil {
+		return nil, err
+	}
+	redisConfig.Timeout = timeout
+	if err := setRedisCreds(c, redisConfig); err != nil {
+		return nil, err
+	}
+	tlsOptions := &redisenterprise.TLSOptions{}
+	if tls := cluster.Spec.TLS; tls != nil {
+		if tls.CASecretRef != nil {
+			caCert, err := secretKey(c, tls.CASecretRef.Name, tls.CASecretRef.Namespace, caCertKey)
+			if err != nil {
+				return nil, err
+			}
+			tlsOptions.CACert = caCert
+		} else if tls.CAConfigMapRef != nil {
+			caCert, err := configMapKey(c, tls.CAConfigMapRef.Name, tls.CAConfigMapRef.Namespace, caCertKey)
+			if err != nil {
+				return nil, err
+			}
+			tlsOptions.CACert = caCert
+		}
+		if tls.ClientCertSecretRef != nil {
+			if err := setClientCert(c, tls.ClientCertSecretRef.Name, tls.ClientCertSecretRef.Namespace, tlsOptions); err != nil {
+				return nil, err
+			}
+		}
+		tlsOptions.InsecureSkipVerify = tls.InsecureSkipVerify
+	}
+	redisConfig.TLS = tlsOptions
+	return redisConfig, nil
+}
+
+// FromEnv loads the configurations of the cluster set by the operator env vars
+func FromEnv(c client.Client) (*RedisConfig, error) {
+	redisConfig := &RedisConfig{}
+	// Get Redis Credentials Secret name
+	redisCredSecretName, err := GetRedisCredSecretName()
+	if err != nil {
+		return nil, err
+	}
+	// Get Redis Namespace
+	redisNamespace, err := GetRedisNamespace()
+	if err != nil {
+		return nil, err
+	}
+	// Get Redis API url
+	redisServiceName, err := GetRedisApiUrl()
+	if err != nil {
+		return nil, err
+	}
+	// Get Redis API request timeout
+	timeout, err := GetRedisAPITimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	redisConfig.Namespace = redisNamespace
+	redisConfig.CredSecret = redisCredSecretName
+	redisConfig.APIUrl = redisServiceName
+	redisConfig.Timeout = timeout
+	// Set Redis Credentials
+	if err := setRedisCreds(c, redisConfig); err != nil {
+		return nil, err
+	}
+	// Set Redis API TLS trust
+	if err := setRedisTLS(c, redisConfig); err != nil {
+		return nil, err
+	}
+	return redisConfig, nil
+}
+
+// ExportLocation loads the export location of the RedisEnterpriseCluster,
+// or of the cluster set by the operator env vars if the cluster is nil
+func ExportLocation(c client.Client, cluster *rdbcv1alpha1.RedisEnterpriseCluster) (redisenterprise.ExportLocation, error) {
+	var name, namespace string
+	if cluster == nil {
+		name = os.Getenv(RedisExportLocationSecret)
+		namespace = os.Getenv(RedisNS)
+		if name == "" {
+			return nil, fmt.Errorf("no export location, %s must be set", RedisExportLocationSecret)
+		}
+	} else {
+		if cluster.Spec.ExportLocationSecretRef == nil {
+			return nil, fmt.Errorf("no export location, set spec.exportLocationSecretRef of RedisEnterpriseCluster %s", cluster.Name)
+		}
+		name = cluster.Spec.ExportLocationSecretRef.Name
+		namespace = cluster.Spec.ExportLocationSecretRef.Namespace
+	}
+	data, err := secretKey(c, name, namespace, exportLocationKey)
+	if err != nil {
+		return nil, err
+	}
+	location := redisenterprise.ExportLocation{}
+	if err := json.Unmarshal(data, &location); err != nil {
+		return nil, fmt.Errorf("invalid export location in secret: %v in namespace: %v: %v", name, namespace, err)
+	}
+	if _, ok := location["type"]; !ok {
+		return nil, fmt.Errorf("export location in secret: %v in namespace: %v has no type", name, namespace)
+	}
+	return location, nil
+}
+
+// BackupLocation returns the export location of the RdbcBackup,
+// the S3 backups are exported to <subdir>/<namespace>/<backup name>
+func BackupLocation(c client.Client, backup *rdbcv1alpha1.RdbcBackup) (redisenterprise.ExportLocation, error) {
+	destination := backup.Spec.Destination.DeepCopy()
+	if destination.S3 != nil {
+		destination.S3.Subdir = path.Join(destination.S3.Subdir, backup.Namespace, backup.Name)
+	}
+	return DestinationLocation(c, backup.Namespace, destination)
+}
+
+// BackupExportPrefix returns the prefix of the S3 objects exported by the RdbcBackup, <subdir>/<namespace>/<backup name>/
+func BackupExportPrefix(backup *rdbcv1alpha1.RdbcBackup) string {
+	if backup.Spec.Destination.S3 == nil {
+		return ""
+	}
+	return path.Join(backup.Spec.Destination.S3.Subdir, backup.Namespace, backup.Name) + "/"
+}
+
+// S3Credentials returns the access key id and the secret access key of the S3 destination
+// from the credentials Secret in the namespace
+func S3Credentials(c client.Client, namespace string, s3 *rdbcv1alpha1.S3Destination) (string, string, error) {
+	name := s3.CredentialsSecretRef.Name
+	accessKeyId, err := secretKey(c, name, namespace, accessKeyIdKey)
+	if err != nil {
+		return "", "", err
+	}
+	secretAccessKey, err := secretKey(c, name, namespace, secretAccessKeyKey)
+	if err != nil {
+		return "", "", err
+	}
+	return string(accessKeyId), string(secretAccessKey), nil
+}
+
+// DestinationLocation returns the Redis Enterprise location of the backup destination,
+// the S3 credentials are read from the Secret in the namespace
+func DestinationLocation(c client.Client, namespace string, destination *rdbcv1alpha1.BackupDestination) (redisenterprise.ExportLocation, error) {
+	if destination.MountPoint != nil {
+		return redisenterprise.ExportLocation{
+			"type": "mount_point",
+			"path": destination.MountPoint.Path,
+		}, nil
+	}
+	s3 := destination.S3
+	if s3 == nil {
+		return nil, fmt.Errorf("backup destination has no storage")
+	}
+	accessKeyId, secretAccessKey, err := S3Credentials(c, namespace, s3)
+	if err != nil {
+		return nil, err
+	}
+	location := redisenterprise.ExportLocation{
+		"type":              "s3",
+		"bucket_name":       s3.Bucket,
+		"subdir":            s3.Subdir,
+		"access_key_id":     accessKeyId,
+		"secret_access_key": secretAccessKey,
+	}
+	if s3.Endpoint != "" {
+		location["s3_url"] = s3.Endpoint
+	}
+	return location, nil
+}
+
+// IsEnvConfigured returns true if the operator env vars set the Redis API
+func IsEnvConfigured() bool {
+	_, err := GetRedisApiUrl()
+	return err == nil
+}
+
+// setRedisTLS loads the CA bundle and the client certificate used to connect to the Redis API,
+// the Secrets and the ConfigMap are expected in the Redis namespace, next to the credentials Secret
+func setRedisTLS(c client.Client, redisConfig *RedisConfig) error {
+	tlsOptions := &redisenterprise.TLSOptions{}
+	if secretName, found := os.LookupEnv(RedisCASecret); found && secretName != "" {
+		caCert, err := secretKey(c, secretName, redisConfig.Namespace, caCertKey)
+		if err != nil {
+			return err
+		}
+		tlsOptions.CACert = caCert
+	} else if configMapName, found := os.LookupEnv(RedisCAConfigMap); found && configMapName != "" {
+		caCert, err := configMapKey(c, configMapName, redisConfig.Namespace, caCertKey)
+		if err != nil {
+			return err
+		}
+		tlsOptions.CACert = caCert
+	}
+	if secretName, found := os.LookupEnv(RedisClientCertSecret); found && secretName != "" {
+		if err := setClientCert(c, secretName, redisConfig.Namespace, tlsOptions); err != nil {
+			return err
+		}
+	}
+	// Skipping the verification must be explicitly requested
+	tlsOptions.InsecureSkipVerify = os.Getenv(RedisInsecureSkipVerify) == "true"
+	redisConfig.TLS = tlsOptions
+	return nil
+}
+
+func setRedisCreds(c client.Client, redisConfig *RedisConfig) error {
+	redisSecret := &corev1.Secret{}
+	err := c.Get(
+		context.TODO(),
+		client.ObjectKey{Name: redisConfig.CredSecret, Namespace: redisConfig.Namespace},
+		redisSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get Redis credentials Secret: %v in namespace: %v: %v",
+			redisConfig.CredSecret,
+			redisConfig.Namespace,
+			err)
+	}
+	if _, ok := redisSecret.Data["password"]; ok {
+		redisConfig.Password = string(redisSecret.Data["password"])
+	} else {
+		return fmt.Errorf("failed to get a passwrod from secrets: %v in namespace: %v",
+			redisConfig.CredSecret,
+			redisConfig.Namespace)
+	}
+	if _, ok := redisSecret.Data["username"]; ok {
+		redisConfig.Username = string(redisSecret.Data["username"])
+	} else {
+		return fmt.Errorf("failed to get a username from secrets: %v in namespace: %v",
+			redisConfig.CredSecret,
+			redisConfig.Namespace)
+	}
+	return nil
+}
+
+func setClientCert(c client.Client, name string, namespace string, tlsOptions *redisenterprise.TLSOptions) error {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+		return fmt.Errorf("failed to get client certificate Secret: %v in namespace: %v: %v", name, namespace, err)
+	}
+	tlsOptions.ClientCert = secret.Data[corev1.TLSCertKey]
+	tlsOptions.ClientKey = secret.Data[corev1.TLSPrivateKeyKey]
+	return nil
+}
+
+func secretKey(c client.Client, name string, namespace string, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret: %v in namespace: %v: %v", name, namespace, err)
+	}
+	if _, ok := secret.Data[key]; !ok {
+		return nil, fmt.Errorf("failed to get %s from secret: %v in namespace: %v", key, name, namespace)
+	}
+	return secret.Data[key], nil
+}
+
+func configMapKey(c client.Client, name string, namespace string, key string) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap: %v in namespace: %v: %v", name, namespace, err)
+	}
+	if _, ok := configMap.Data[key]; !ok {
+		return nil, fmt.Errorf("failed to get %s from configmap: %v in namespace: %v", key, name, namespace)
+	}
+	return []byte(configMap.Data[key]), nil
+}
+
+func GetRedisCredSecretName() (string, error) {
+	redisCredSecret, found := os.LookupEnv(RedisCredSecret)
+	if !found {
+		return "", fmt.Errorf("%s must be set", RedisCredSecret)
+	}
+	return redisCredSecret, nil
+}
+
+func GetRedisNamespace() (string, error) {
+	redisCredSecret, found := os.LookupEnv(RedisNS)
+	if !found {
+		return "", fmt.Errorf("%s must be set", RedisNS)
+	}
+	return redisCredSecret, nil
+}
+
+func GetRedisApiUrl() (string, error) {
+	redisAPIUrl, found := os.LookupEnv(RedisAPI)
+	if !found {
+		return "", fmt.Errorf("%s must be set", RedisAPI)
+	}
+	return redisAPIUrl, nil
+}
+
+// GetRedisAPITimeout returns the time limit of a single Redis API request, defaults to redisenterprise.DefaultTimeout
+func GetRedisAPITimeout() (time.Duration, error) {
+	value := os.Getenv(RedisAPITimeout)
+	if value == "" {
+		return redisenterprise.DefaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, e.g. 30s, got: %s", RedisAPITimeout, value)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,241 @@
+// Package redisenterprise is a client for the Redis Enterprise REST API
+// https://storage.googleapis.com/rlecrestapi/rest-html/http_rest_api.html
+package redisenterprise
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/rdbc-operator/pkg/metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("redisenterprise")
+
+// DefaultTimeout is the default time limit of a single API request, including reading the response
+const DefaultTimeout = 30 * time.Second
+
+// Client is the Redis Enterprise REST API
+type Client interface {
+	// GetBdb returns the bdb by uid
+	GetBdb(uid int32) (*Bdb, error)
+	// ListBdbs returns all the bdbs in the cluster
+	ListBdbs() ([]Bdb, error)
+	// CreateBdb creates a new bdb, the returned bdb holds the action_uid of the creation
+	CreateBdb(bdb *Bdb) (*Bdb, error)
+	// UpdateBdb changes the bdb in place, only the set fields of the bdb are updated
+	UpdateBdb(uid int32, bdb *Bdb) (*Bdb, error)
+	// DeleteBdb deletes the bdb by uid
+	DeleteBdb(uid int32) error
+	// ExportBdb starts the export of the bdb data to the location, the returned action tracks the export
+	ExportBdb(uid int32, location ExportLocation) (*Action, error)
+	// ImportBdb starts the import of the files into the bdb, the returned action tracks the import
+	ImportBdb(uid int32, sources []ImportSource) (*Action, error)
+	// FlushBdb deletes all the keys of the bdb
+	FlushBdb(uid int32) error
+	// GetAction returns the action by uid
+	GetAction(uid string) (*Action, error)
+	// ListActions returns all the running and recently completed actions
+	ListActions() ([]Action, error)
+	// ListUsers returns all the cluster users
+	ListUsers() ([]User, error)
+	// GetCluster returns the cluster configurations
+	GetCluster() (*Cluster, error)
+	// ListNodes returns the cluster nodes
+	ListNodes() ([]Node, error)
+	// GetBdbStats returns the last stats interval of the bdb
+	GetBdbStats(uid int32) (*BdbStats, error)
+	// ListModules returns the modules installed on the cluster
+	ListModules() ([]Module, error)
+}
+
+type client struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// blank assignment to verify that client implements Client
+var _ Client = &client{}
+
+// NewClient returns Redis Enterprise API client for the API url and the admin credentials.
+// The client uses a dedicated transport with the given TLS configurations,
+// thus the TLS settings of the other HTTP clients in the process are not affected.
+// The requests are failed after the timeout, thus a hung endpoint doesn't block the caller.
+func NewClient(url string, username string, password string, tlsConfig *tls.Config, timeout time.Duration) Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &client{
+		url:        url,
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Transport: transport, Timeout: timeout},
+	}
+}
+
+func (c *client) GetBdb(uid int32) (*Bdb, error) {
+	bdb := &Bdb{}
+	if err := c.do("GET", fmt.Sprintf("/v1/bdbs/%d", uid), nil, bdb); err != nil {
+		return nil, err
+	}
+	return bdb, nil
+}
+
+func (c *client) ListBdbs() ([]Bdb, error) {
+	var bdbs []Bdb
+	if err := c.do("GET", "/v1/bdbs", nil, &bdbs); err != nil {
+		return nil, err
+	}
+	return bdbs, nil
+}
+
+func (c *client) CreateBdb(bdb *Bdb) (*Bdb, error) {
+	created := &Bdb{}
+	if err := c.do("POST", "/v1/bdbs", bdb, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (c *client) UpdateBdb(uid int32, bdb *Bdb) (*Bdb, error) {
+	updated := &Bdb{}
+	if err := c.do("PUT", fmt.Sprintf("/v1/bdbs/%d", uid), bdb, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *client) DeleteBdb(uid int32) error {
+	return c.do("DELETE", fmt.Sprintf("/v1/bdbs/%d", uid), nil, nil)
+}
+
+func (c *client) ExportBdb(uid int32, location ExportLocation) (*Action, error) {
+	action := &Action{}
+	if err := c.do("POST", fmt.Sprintf("/v1/bdbs/%d/actions/export", uid), &exportRequest{ExportLocation: location}, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+func (c *client) ImportBdb(uid int32, sources []ImportSource) (*Action, error) {
+	action := &Action{}
+	if err := c.do("POST", fmt.Sprintf("/v1/bdbs/%d/actions/import", uid), &importRequest{DatasetImportSources: sources}, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+func (c *client) FlushBdb(uid int32) error {
+	return c.do("PUT", fmt.Sprintf("/v1/bdbs/%d/actions/flush", uid), nil, nil)
+}
+
+func (c *client) GetAction(uid string) (*Action, error) {
+	action := &Action{}
+	if err := c.do("GET", fmt.Sprintf("/v1/actions/%s", uid), nil, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+func (c *client) ListActions() ([]Action, error) {
+	var actions []Action
+	if err := c.do("GET", "/v1/actions", nil, &actions); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+func (c *client) ListUsers() ([]User, error) {
+	var users []User
+	if err := c.do("GET", "/v1/users", nil, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (c *client) GetCluster() (*Cluster, error) {
+	cluster := &Cluster{}
+	if err := c.do("GET", "/v1/cluster", nil, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+func (c *client) ListNodes() ([]Node, error) {
+	var nodes []Node
+	if err := c.do("GET", "/v1/nodes", nil, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (c *client) ListModules() ([]Module, error) {
+	var modules []Module
+	if err := c.do("GET", "/v1/modules", nil, &modules); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+func (c *client) GetBdbStats(uid int32) (*BdbStats, error) {
+	// The API returns the stats keyed by bdb uid
+	stats := map[string]*BdbStats{}
+	if err := c.do("GET", fmt.Sprintf("/v1/bdbs/stats/last/%d", uid), nil, &stats); err != nil {
+		return nil, err
+	}
+	bdbStats, ok := stats[fmt.Sprint(uid)]
+	if !ok || bdbStats == nil {
+		return nil, fmt.Errorf("stats for bdb %d are missing in the response", uid)
+	}
+	return bdbStats, nil
+}
+
+// do executes the API request, in is marshalled as the request body if it's not nil,
+// the response body is unmarshalled into out if it's not nil
+func (c *client) do(method string, path string, in interface{}, out interface{}) error {
+	url := c.url + path
+	log.V(1).Info(fmt.Sprintf("%s %s", method, url))
+	var body []byte
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body for %s %s: %v", method, url, err)
+		}
+		body = b
+	}
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to compose %s request for url: %s: %v", method, url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		metrics.ObserveRedisAPIRequest(method, path, 0, time.Since(start))
+		return fmt.Errorf("failed to execute %s request for url: %s: %v", method, url, err)
+	}
+	metrics.ObserveRedisAPIRequest(method, path, resp.StatusCode, time.Since(start))
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response of %s request for url: %s: %v", method, url, err)
+	}
+	// Assume any code bellow 299 is valid
+	if resp.StatusCode > 299 {
+		return newError(resp.StatusCode, respBody)
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response of %s request for url: %s: %v", method, url, err)
+	}
+	return nil
+}
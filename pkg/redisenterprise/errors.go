@@ -0,0 +1,46 @@
+package redisenterprise
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error is returned for any non 2xx API response,
+// it carries the error_code and the description reported by Redis Enterprise
+type Error struct {
+	StatusCode  int    `json:"-"`
+	ErrorCode   string `json:"error_code"`
+	Description string `json:"description"`
+}
+
+func (e *Error) Error() string {
+	if e.ErrorCode == "" {
+		return fmt.Sprintf("redis enterprise api error, status code: %d, %s", e.StatusCode, e.Description)
+	}
+	return fmt.Sprintf("redis enterprise api error, status code: %d, error code: %s, %s", e.StatusCode, e.ErrorCode, e.Description)
+}
+
+// newError composes Error from the response status code and body,
+// if the body is not a valid API error the raw body is used as the description
+func newError(statusCode int, body []byte) *Error {
+	e := &Error{}
+	if err := json.Unmarshal(body, e); err != nil || (e.ErrorCode == "" && e.Description == "") {
+		e.Description = string(body)
+	}
+	e.StatusCode = statusCode
+	return e
+}
+
+// IsNotFound returns true if the err is an API error for missing resource
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// StatusCode returns the response status code of the API error, 0 for any other error
+func StatusCode(err error) int {
+	if e, ok := err.(*Error); ok {
+		return e.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,598 @@
+// Package fake is an in-process Redis Enterprise REST API for tests,
+// it keeps bdbs, endpoints, actions and users in memory and supports fault injection
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rdbc-operator/pkg/redisenterprise"
+)
+
+const (
+	// Username is the admin username accepted by the fake server
+	Username = "admin@fake.local"
+	// Password is the admin password accepted by the fake server
+	Password = "fake"
+	// ClusterName is the name of the fake cluster
+	ClusterName = "fake.local"
+	// SoftwareVersion is the Redis Enterprise version of the fake cluster nodes
+	SoftwareVersion = "6.0.20-69"
+
+	firstEndpointPort = 10000
+)
+
+// Fault is injected into the requests matching Method and Path.
+// Empty Method matches any method, Path matches as a prefix, empty Path matches any path.
+type Fault struct {
+	Method string
+	Path   string
+	// StatusCode responds with the status code and an API error, e.g. 500 or 404
+	StatusCode int
+	// Latency delays the response
+	Latency time.Duration
+	// MalformedJSON responds with 200 and a body which is not a valid JSON
+	MalformedJSON bool
+	// Times is the number of requests the fault applies to, 0 applies to all the requests
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	return strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// Server is the fake Redis Enterprise API server
+type Server struct {
+	*httptest.Server
+	// PendingCreates keeps created bdbs pending and their actions running until CompleteAction is called
+	PendingCreates bool
+	// PendingExports keeps the export actions running until CompleteAction is called
+	PendingExports bool
+	// PendingImports keeps the import actions running until CompleteAction is called
+	PendingImports bool
+
+	mu           sync.Mutex
+	bdbs         map[int32]*redisenterprise.Bdb
+	actions      map[string]*redisenterprise.Action
+	users        []redisenterprise.User
+	stats        map[int32]*redisenterprise.BdbStats
+	modules      []redisenterprise.Module
+	nodes        []redisenterprise.Node
+	exports      map[int32][]redisenterprise.ExportLocation
+	imports      map[int32][][]redisenterprise.ImportSource
+	flushes      map[int32]int
+	faults       []*Fault
+	requests     []string
+	nextPort     int
+	nextUid      int32
+	nextActionId int
+}
+
+// NewServer starts the fake server, the caller should Close it when done
+func NewServer() *Server {
+	s := &Server{
+		bdbs:    map[int32]*redisenterprise.Bdb{},
+		actions: map[string]*redisenterprise.Action{},
+		stats:   map[int32]*redisenterprise.BdbStats{},
+		exports: map[int32][]redisenterprise.ExportLocation{},
+		imports: map[int32][][]redisenterprise.ImportSource{},
+		flushes: map[int32]int{},
+		users:   []redisenterprise.User{{Uid: 1, Name: "admin", Email: Username, Role: "admin"}},
+		modules: []redisenterprise.Module{
+			{Uid: "1", ModuleName: "search", DisplayName: "RediSearch 2", SemanticVersion: "2.0.6"},
+			{Uid: "2", ModuleName: "ReJSON", DisplayName: "RedisJSON", SemanticVersion: "2.0.6"},
+			{Uid: "3", ModuleName: "timeseries", DisplayName: "RedisTimeSeries", SemanticVersion: "1.4.10"},
+			{Uid: "4", ModuleName: "bf", DisplayName: "RedisBloom", SemanticVersion: "2.2.9"},
+		},
+		nodes: []redisenterprise.Node{
+			{Uid: 1, Addr: "127.0.0.1", Status: "active", TotalMemory: 8 * 1024 * 1024 * 1024, SoftwareVersion: SoftwareVersion},
+		},
+		nextPort: firstEndpointPort,
+		nextUid:  1,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// RedisClient returns Redis Enterprise API client for the fake server
+func (s *Server) RedisClient() redisenterprise.Client {
+	return redisenterprise.NewClient(s.URL, Username, Password, nil, redisenterprise.DefaultTimeout)
+}
+
+// InjectFault adds the fault, faults are matched in the injection order
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all the injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the served requests as "METHOD path"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// AddBdb stores the bdb as is, an endpoint is added if the bdb has none
+func (s *Server) AddBdb(bdb redisenterprise.Bdb) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if bdb.Uid == 0 {
+		bdb.Uid = s.newUid()
+	}
+	if bdb.Status == "" {
+		bdb.Status = redisenterprise.BdbStatusActive
+	}
+	if len(bdb.Endpoints) == 0 {
+		bdb.Endpoints = []redisenterprise.Endpoint{s.newEndpoint(bdb.Uid)}
+	}
+	s.bdbs[bdb.Uid] = &bdb
+}
+
+// Bdb returns a copy of the stored bdb
+func (s *Server) Bdb(uid int32) (redisenterprise.Bdb, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bdb, ok := s.bdbs[uid]
+	if !ok {
+		return redisenterprise.Bdb{}, false
+	}
+	return *bdb, true
+}
+
+// Bdbs returns copies of all the stored bdbs ordered by uid
+func (s *Server) Bdbs() []redisenterprise.Bdb {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listBdbs()
+}
+
+// DeleteBdb removes the bdb, as if it was deleted manually
+func (s *Server) DeleteBdb(uid int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bdbs, uid)
+}
+
+// AddUser stores the cluster user
+func (s *Server) AddUser(user redisenterprise.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = append(s.users, user)
+}
+
+// SetModules replaces the modules installed on the cluster
+func (s *Server) SetModules(modules []redisenterprise.Module) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.modules = modules
+}
+
+// SetNodes replaces the cluster nodes
+func (s *Server) SetNodes(nodes []redisenterprise.Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes = nodes
+}
+
+// SetBdbStats sets the stats returned for the bdb
+func (s *Server) SetBdbStats(uid int32, stats redisenterprise.BdbStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats[uid] = &stats
+}
+
+// Exports returns the locations the bdb was exported to
+func (s *Server) Exports(uid int32) []redisenterprise.ExportLocation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]redisenterprise.ExportLocation{}, s.exports[uid]...)
+}
+
+// Imports returns the sources of each import into the bdb
+func (s *Server) Imports(uid int32) [][]redisenterprise.ImportSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]redisenterprise.ImportSource{}, s.imports[uid]...)
+}
+
+// Flushes returns the number of times the bdb was flushed
+func (s *Server) Flushes(uid int32) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flushes[uid]
+}
+
+// Action returns a copy of the action
+func (s *Server) Action(uid string) (redisenterprise.Action, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	action, ok := s.actions[uid]
+	if !ok {
+		return redisenterprise.Action{}, false
+	}
+	return *action, true
+}
+
+// CompleteAction completes the action and activates the bdb it was created for
+func (s *Server) CompleteAction(uid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finishAction(uid, redisenterprise.ActionStatusCompleted, "")
+}
+
+// FailAction fails the action with the error
+func (s *Server) FailAction(uid string, actionError string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finishAction(uid, redisenterprise.ActionStatusFailed, actionError)
+}
+
+func (s *Server) finishAction(uid string, status string, actionError string) {
+	action, ok := s.actions[uid]
+	if !ok {
+		return
+	}
+	action.Status = status
+	action.Error = actionError
+	action.Progress = 100
+	for _, bdb := range s.bdbs {
+		if bdb.ActionUid != uid {
+			continue
+		}
+		if status == redisenterprise.ActionStatusCompleted {
+			bdb.Status = redisenterprise.BdbStatusActive
+			bdb.Endpoints = []redisenterprise.Endpoint{s.newEndpoint(bdb.Uid)}
+		}
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			time.Sleep(fault.Latency)
+		}
+		if fault.StatusCode != 0 {
+			writeError(w, fault.StatusCode, "injected_fault", fmt.Sprintf("injected fault for %s %s", r.Method, r.URL.Path))
+			return
+		}
+		if fault.MalformedJSON {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"malformed": `))
+			return
+		}
+	}
+
+	if username, password, ok := r.BasicAuth(); !ok || username != Username || password != Password {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid credentials")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, r)
+}
+
+// matchFault returns the first matching fault, a fault is dropped once it's applied Times times
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v1" {
+		writeError(w, http.StatusNotFound, "not_found", "unknown path")
+		return
+	}
+	switch {
+	case parts[1] == "bdbs" && len(parts) == 2 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.listBdbs())
+	case parts[1] == "bdbs" && len(parts) == 2 && r.Method == "POST":
+		s.createBdb(w, r)
+	case parts[1] == "bdbs" && len(parts) == 5 && parts[2] == "stats" && parts[3] == "last" && r.Method == "GET":
+		s.getBdbStats(w, parts[4])
+	case parts[1] == "bdbs" && len(parts) == 3:
+		s.serveBdb(w, r, parts[2])
+	case parts[1] == "bdbs" && len(parts) == 5 && parts[3] == "actions" && parts[4] == "export" && r.Method == "POST":
+		s.exportBdb(w, r, parts[2])
+	case parts[1] == "bdbs" && len(parts) == 5 && parts[3] == "actions" && parts[4] == "import" && r.Method == "POST":
+		s.importBdb(w, r, parts[2])
+	case parts[1] == "bdbs" && len(parts) == 5 && parts[3] == "actions" && parts[4] == "flush" && r.Method == "PUT":
+		s.flushBdb(w, parts[2])
+	case parts[1] == "actions" && len(parts) == 2 && r.Method == "GET":
+		s.listActions(w)
+	case parts[1] == "actions" && len(parts) == 3 && r.Method == "GET":
+		s.getAction(w, parts[2])
+	case parts[1] == "modules" && len(parts) == 2 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.modules)
+	case parts[1] == "nodes" && len(parts) == 2 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.nodes)
+	case parts[1] == "users" && len(parts) == 2 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.users)
+	case parts[1] == "cluster" && len(parts) == 2 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, redisenterprise.Cluster{Name: ClusterName})
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown path")
+	}
+}
+
+func (s *Server) createBdb(w http.ResponseWriter, r *http.Request) {
+	bdb := &redisenterprise.Bdb{}
+	if !readJSON(w, r, bdb) {
+		return
+	}
+	if bdb.Name == "" || bdb.MemorySize <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid_schema", "name and memory_size are required")
+		return
+	}
+	if bdb.Uid == 0 {
+		bdb.Uid = s.newUid()
+	}
+	if _, ok := s.bdbs[bdb.Uid]; ok {
+		writeError(w, http.StatusConflict, "db_conflict", fmt.Sprintf("database %d already exists", bdb.Uid))
+		return
+	}
+	for _, existing := range s.bdbs {
+		if existing.Name == bdb.Name {
+			writeError(w, http.StatusConflict, "db_conflict", fmt.Sprintf("database named %s already exists", bdb.Name))
+			return
+		}
+	}
+	action := s.newAction("create_bdb")
+	bdb.ActionUid = action.ActionUid
+	if s.PendingCreates {
+		bdb.Status = redisenterprise.BdbStatusPending
+		bdb.Endpoints = nil
+	} else {
+		bdb.Status = redisenterprise.BdbStatusActive
+		bdb.Endpoints = []redisenterprise.Endpoint{s.newEndpoint(bdb.Uid)}
+		action.Status = redisenterprise.ActionStatusCompleted
+		action.Progress = 100
+	}
+	s.bdbs[bdb.Uid] = bdb
+	writeJSON(w, http.StatusOK, bdb)
+}
+
+func (s *Server) serveBdb(w http.ResponseWriter, r *http.Request, uidParam string) {
+	uid, err := strconv.Atoi(uidParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_uid", fmt.Sprintf("invalid uid: %s", uidParam))
+		return
+	}
+	bdb, ok := s.bdbs[int32(uid)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "db_not_exist", fmt.Sprintf("database %d does not exist", uid))
+		return
+	}
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, bdb)
+	case "PUT":
+		// Unmarshal the update on top of the stored bdb, thus only the sent fields are changed
+		updated := *bdb
+		if !readJSON(w, r, &updated) {
+			return
+		}
+		updated.Uid = bdb.Uid
+		*bdb = updated
+		writeJSON(w, http.StatusOK, bdb)
+	case "DELETE":
+		delete(s.bdbs, bdb.Uid)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method)
+	}
+}
+
+func (s *Server) exportBdb(w http.ResponseWriter, r *http.Request, uidParam string) {
+	uid, err := strconv.Atoi(uidParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_uid", fmt.Sprintf("invalid uid: %s", uidParam))
+		return
+	}
+	if _, ok := s.bdbs[int32(uid)]; !ok {
+		writeError(w, http.StatusNotFound, "db_not_exist", fmt.Sprintf("database %d does not exist", uid))
+		return
+	}
+	request := &struct {
+		ExportLocation redisenterprise.ExportLocation `json:"export_location"`
+	}{}
+	if !readJSON(w, r, request) {
+		return
+	}
+	if request.ExportLocation["type"] == nil {
+		writeError(w, http.StatusBadRequest, "invalid_schema", "export_location type is required")
+		return
+	}
+	s.exports[int32(uid)] = append(s.exports[int32(uid)], request.ExportLocation)
+	action := s.newAction("export_bdb")
+	if !s.PendingExports {
+		action.Status = redisenterprise.ActionStatusCompleted
+		action.Progress = 100
+	}
+	writeJSON(w, http.StatusOK, action)
+}
+
+func (s *Server) importBdb(w http.ResponseWriter, r *http.Request, uidParam string) {
+	uid, err := strconv.Atoi(uidParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_uid", fmt.Sprintf("invalid uid: %s", uidParam))
+		return
+	}
+	if _, ok := s.bdbs[int32(uid)]; !ok {
+		writeError(w, http.StatusNotFound, "db_not_exist", fmt.Sprintf("database %d does not exist", uid))
+		return
+	}
+	request := &struct {
+		DatasetImportSources []redisenterprise.ImportSource `json:"dataset_import_sources"`
+	}{}
+	if !readJSON(w, r, request) {
+		return
+	}
+	if len(request.DatasetImportSources) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_schema", "dataset_import_sources is required")
+		return
+	}
+	for _, source := range request.DatasetImportSources {
+		if source["type"] == nil {
+			writeError(w, http.StatusBadRequest, "invalid_schema", "dataset_import_sources type is required")
+			return
+		}
+	}
+	s.imports[int32(uid)] = append(s.imports[int32(uid)], request.DatasetImportSources)
+	action := s.newAction("import_bdb")
+	if !s.PendingImports {
+		action.Status = redisenterprise.ActionStatusCompleted
+		action.Progress = 100
+	}
+	writeJSON(w, http.StatusOK, action)
+}
+
+func (s *Server) flushBdb(w http.ResponseWriter, uidParam string) {
+	uid, err := strconv.Atoi(uidParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_uid", fmt.Sprintf("invalid uid: %s", uidParam))
+		return
+	}
+	if _, ok := s.bdbs[int32(uid)]; !ok {
+		writeError(w, http.StatusNotFound, "db_not_exist", fmt.Sprintf("database %d does not exist", uid))
+		return
+	}
+	s.flushes[int32(uid)]++
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) getBdbStats(w http.ResponseWriter, uidParam string) {
+	uid, err := strconv.Atoi(uidParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_uid", fmt.Sprintf("invalid uid: %s", uidParam))
+		return
+	}
+	if _, ok := s.bdbs[int32(uid)]; !ok {
+		writeError(w, http.StatusNotFound, "db_not_exist", fmt.Sprintf("database %d does not exist", uid))
+		return
+	}
+	stats, ok := s.stats[int32(uid)]
+	if !ok {
+		stats = &redisenterprise.BdbStats{}
+	}
+	writeJSON(w, http.StatusOK, map[string]*redisenterprise.BdbStats{uidParam: stats})
+}
+
+func (s *Server) listActions(w http.ResponseWriter) {
+	actions := []redisenterprise.Action{}
+	for _, action := range s.actions {
+		actions = append(actions, *action)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].ActionUid < actions[j].ActionUid })
+	writeJSON(w, http.StatusOK, actions)
+}
+
+func (s *Server) getAction(w http.ResponseWriter, uid string) {
+	action, ok := s.actions[uid]
+	if !ok {
+		writeError(w, http.StatusNotFound, "action_not_exist", fmt.Sprintf("action %s does not exist", uid))
+		return
+	}
+	writeJSON(w, http.StatusOK, action)
+}
+
+func (s *Server) listBdbs() []redisenterprise.Bdb {
+	bdbs := []redisenterprise.Bdb{}
+	for _, bdb := range s.bdbs {
+		bdbs = append(bdbs, *bdb)
+	}
+	sort.Slice(bdbs, func(i, j int) bool { return bdbs[i].Uid < bdbs[j].Uid })
+	return bdbs
+}
+
+func (s *Server) newUid() int32 {
+	for {
+		uid := s.nextUid
+		s.nextUid++
+		if _, ok := s.bdbs[uid]; !ok {
+			return uid
+		}
+	}
+}
+
+func (s *Server) newEndpoint(uid int32) redisenterprise.Endpoint {
+	port := s.nextPort
+	s.nextPort++
+	return redisenterprise.Endpoint{
+		Uid:     fmt.Sprintf("%d:1", uid),
+		DnsName: fmt.Sprintf("redis-%d.%s", port, ClusterName),
+		Port:    port,
+		Addr:    []string{"127.0.0.1"},
+	}
+}
+
+func (s *Server) newAction(name string) *redisenterprise.Action {
+	s.nextActionId++
+	action := &redisenterprise.Action{
+		ActionUid: fmt.Sprintf("action-%d", s.nextActionId),
+		Name:      name,
+		Status:    redisenterprise.ActionStatusRunning,
+	}
+	s.actions[action.ActionUid] = action
+	return action
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, into interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return false
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_schema", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errorCode string, description string) {
+	writeJSON(w, statusCode, redisenterprise.Error{ErrorCode: errorCode, Description: description})
+}
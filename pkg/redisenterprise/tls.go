@@ -0,0 +1,45 @@
+package redisenterprise
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// TLSOptions are the PEM encoded certificates used to connect to the API
+type TLSOptions struct {
+	// CACert is the CA bundle used to verify the API certificate, the system roots are used if it's empty
+	CACert []byte
+	// ClientCert and ClientKey are presented to the API for mTLS, optional
+	ClientCert []byte
+	ClientKey  []byte
+	// InsecureSkipVerify disables the API certificate verification
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig returns the TLS configurations for the API transport
+func NewTLSConfig(opts *TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if opts == nil {
+		return tlsConfig, nil
+	}
+	if len(opts.CACert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(opts.CACert) {
+			return nil, fmt.Errorf("failed to parse CA bundle, no valid PEM certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(opts.ClientCert) > 0 || len(opts.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if opts.InsecureSkipVerify {
+		log.Info("WARNING: certificate verification of the Redis Enterprise API is disabled, the connection is not secure")
+		tlsConfig.InsecureSkipVerify = true
+	}
+	return tlsConfig, nil
+}
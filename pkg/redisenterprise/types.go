@@ -0,0 +1,185 @@
+package redisenterprise
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bdb statuses
+const (
+	BdbStatusPending        = "pending"
+	BdbStatusActive         = "active"
+	BdbStatusCreationFailed = "creation-failed"
+	BdbStatusDeleting       = "delete-pending"
+)
+
+// Action statuses
+const (
+	ActionStatusQueued    = "queued"
+	ActionStatusRunning   = "running"
+	ActionStatusCompleted = "completed"
+	ActionStatusFailed    = "failed"
+	ActionStatusCancelled = "cancelled"
+)
+
+// Bdb is the Redis Enterprise database,
+// used as the request body for create and update and as the API response.
+// Empty fields are omitted, thus on update only the set fields are changed.
+type Bdb struct {
+	Uid        int32      `json:"uid,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Type       string     `json:"type,omitempty"`
+	MemorySize int64      `json:"memory_size,omitempty"`
+	Password   string     `json:"authentication_redis_pass,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	Endpoints  []Endpoint `json:"endpoints,omitempty"`
+	// Boolean fields are pointers, thus the update may explicitly disable them
+	Replication   *bool           `json:"replication,omitempty"`
+	Sharding      *bool           `json:"sharding,omitempty"`
+	ShardsCount   int             `json:"shards_count,omitempty"`
+	ShardKeyRegex []ShardKeyRegex `json:"shard_key_regex,omitempty"`
+	OSSCluster    *bool           `json:"oss_cluster,omitempty"`
+	ProxyPolicy   string          `json:"proxy_policy,omitempty"`
+	// Persistence and eviction
+	DataPersistence string           `json:"data_persistence,omitempty"`
+	AofPolicy       string           `json:"aof_policy,omitempty"`
+	SnapshotPolicy  []SnapshotPolicy `json:"snapshot_policy,omitempty"`
+	EvictionPolicy  string           `json:"eviction_policy,omitempty"`
+	// Modules, may be set on creation only
+	ModuleList []BdbModule `json:"module_list,omitempty"`
+	// ActionUid is set by the API on the bdb creation response
+	ActionUid string `json:"action_uid,omitempty"`
+}
+
+// ShardKeyRegex is the regex used to extract the hash tag from the keys
+type ShardKeyRegex struct {
+	Regex string `json:"regex"`
+}
+
+// SnapshotPolicy takes a snapshot every Secs seconds if there were at least Writes writes
+type SnapshotPolicy struct {
+	Secs   int `json:"secs"`
+	Writes int `json:"writes"`
+}
+
+// BdbModule is the module loaded by the bdb
+type BdbModule struct {
+	ModuleId        string `json:"module_id,omitempty"`
+	ModuleName      string `json:"module_name"`
+	ModuleArgs      string `json:"module_args"`
+	SemanticVersion string `json:"semantic_version,omitempty"`
+}
+
+// Module is the module installed on the cluster
+type Module struct {
+	Uid             string `json:"uid"`
+	ModuleName      string `json:"module_name"`
+	DisplayName     string `json:"display_name,omitempty"`
+	SemanticVersion string `json:"semantic_version"`
+}
+
+// Endpoint is the bdb endpoint
+type Endpoint struct {
+	Uid     string   `json:"uid,omitempty"`
+	DnsName string   `json:"dns_name"`
+	Port    int      `json:"port"`
+	Addr    []string `json:"addr,omitempty"`
+}
+
+// Action is a long running cluster operation
+type Action struct {
+	ActionUid string  `json:"action_uid"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Progress  float64 `json:"progress"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ExportLocation is the storage the db is exported to, e.g. {"type": "s3", "bucket_name": "backups", ...},
+// the fields depend on the storage type
+type ExportLocation map[string]interface{}
+
+// The export location fields which are safe to record, the other fields may hold credentials
+var exportLocationFields = []string{"type", "bucket_name", "subdir", "filename", "path", "container", "region", "s3_url"}
+
+// Describe returns the export location without the credentials, e.g. "type=s3 bucket_name=backups"
+func (l ExportLocation) Describe() string {
+	var fields []string
+	for _, field := range exportLocationFields {
+		if value, ok := l[field]; ok {
+			fields = append(fields, fmt.Sprintf("%s=%v", field, value))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// exportRequest is the request body of the db export
+type exportRequest struct {
+	ExportLocation    ExportLocation `json:"export_location"`
+	EmailNotification bool           `json:"email_notification"`
+}
+
+// ImportSource is the storage and the file the db data is imported from, e.g. {"type": "s3", "bucket_name": "backups", "filename": "db.rdb.gz", ...},
+// the fields depend on the storage type
+type ImportSource map[string]interface{}
+
+// Describe returns the import source without the credentials, e.g. "type=s3 bucket_name=backups filename=db.rdb.gz"
+func (s ImportSource) Describe() string {
+	return ExportLocation(s).Describe()
+}
+
+// importRequest is the request body of the db import
+type importRequest struct {
+	DatasetImportSources []ImportSource `json:"dataset_import_sources"`
+	EmailNotification    bool           `json:"email_notification"`
+}
+
+// User is the cluster user
+type User struct {
+	Uid   int32  `json:"uid"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Cluster is the cluster configurations
+type Cluster struct {
+	Name string `json:"name"`
+}
+
+// Node is the cluster node
+type Node struct {
+	Uid             int32  `json:"uid"`
+	Addr            string `json:"addr"`
+	Status          string `json:"status"`
+	TotalMemory     int64  `json:"total_memory"`
+	SoftwareVersion string `json:"software_version"`
+}
+
+// BdbStats is a single stats interval of the bdb
+type BdbStats struct {
+	UsedMemory       float64 `json:"used_memory"`
+	OpsPerSec        float64 `json:"instantaneous_ops_per_sec"`
+	ConnectedClients float64 `json:"conns"`
+	EvictedObjects   float64 `json:"evicted_objects"`
+}
+
+// Bool returns a pointer to the bool value
+func Bool(v bool) *bool {
+	return &v
+}
+
+// BoolValue returns the value of the bool pointer, false if it's nil
+func BoolValue(v *bool) bool {
+	return v != nil && *v
+}
+
+// MegabytesToBytes converts the size in Megabytes, the unit of the Rdbc size, to the API memory size in bytes
+func MegabytesToBytes(size int) int64 {
+	return int64(size) * 1024 * 1024
+}
+
+// BytesToMegabytes converts the API memory size in bytes to Megabytes
+func BytesToMegabytes(size int64) int {
+	return int(size / 1024 / 1024)
+}
@@ -0,0 +1,219 @@
+// Package s3 is a minimal client of the S3 compatible storages, e.g. AWS S3 or MinIO,
+// it lists and deletes the exported objects. The requests are signed by AWS Signature Version 4
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRegion is the region the requests are signed for if not set, MinIO accepts it as well
+	DefaultRegion = "us-east-1"
+
+	// DefaultTimeout is the time limit of a single request, including reading the response
+	DefaultTimeout = 30 * time.Second
+
+	// emptyPayloadHash is the hex encoded sha256 of the empty body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	timeFormat       = "20060102T150405Z"
+	dateFormat       = "20060102"
+)
+
+// Client lists and deletes the objects of the S3 compatible storage
+type Client interface {
+	// ListObjects returns the keys of the bucket objects with the prefix
+	ListObjects(bucket string, prefix string) ([]string, error)
+	// DeleteObject deletes the object by key, the missing object is not an error
+	DeleteObject(bucket string, key string) error
+}
+
+type client struct {
+	endpoint        string
+	region          string
+	accessKeyId     string
+	secretAccessKey string
+	httpClient      *http.Client
+	// now returns the signing time
+	now func() time.Time
+}
+
+// blank assignment to verify that client implements Client
+var _ Client = &client{}
+
+// NewClient returns S3 client of the endpoint, e.g. http://minio.minio:9000,
+// AWS S3 of the region is used if the endpoint is empty. The buckets are addressed by path.
+func NewClient(endpoint string, region string, accessKeyId string, secretAccessKey string) Client {
+	if region == "" {
+		region = DefaultRegion
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	return &client{
+		endpoint:        strings.TrimSuffix(endpoint, "/"),
+		region:          region,
+		accessKeyId:     accessKeyId,
+		secretAccessKey: secretAccessKey,
+		httpClient:      &http.Client{Timeout: DefaultTimeout},
+		now:             time.Now,
+	}
+}
+
+// listBucketResult is the ListObjectsV2 response
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (c *client) ListObjects(bucket string, prefix string) ([]string, error) {
+	var keys []string
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		body, err := c.do("GET", "/"+bucket, query)
+		if err != nil {
+			return nil, err
+		}
+		result := &listBucketResult{}
+		if err := xml.Unmarshal(body, result); err != nil {
+			return nil, fmt.Errorf("failed to parse the objects list of bucket: %s: %v", bucket, err)
+		}
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (c *client) DeleteObject(bucket string, key string) error {
+	_, err := c.do("DELETE", "/"+bucket+"/"+key, nil)
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// do sends the signed request and returns the response body, any non 2xx response is returned as Error
+func (c *client) do(method string, path string, query url.Values) ([]byte, error) {
+	request, err := http.NewRequest(method, c.endpoint+escapePath(path), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.URL.RawQuery = canonicalQuery(query)
+	c.sign(request, c.now().UTC())
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, newError(response.StatusCode, body)
+	}
+	return body, nil
+}
+
+// sign sets the Signature Version 4 Authorization header, all the request headers are signed
+func (c *client) sign(request *http.Request, now time.Time) {
+	request.Header.Set("X-Amz-Date", now.Format(timeFormat))
+	request.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+	scope := strings.Join([]string{now.Format(dateFormat), c.region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", now.Format(timeFormat), scope, hashHex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretAccessKey), now.Format(dateFormat))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKeyId, scope, signedHeaders, signature))
+}
+
+// escapePath encodes each segment of the path by RFC 3986, the slashes are kept
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery returns the query sorted by key, with the keys and the values encoded by RFC 3986
+func canonicalQuery(query url.Values) string {
+	var params []string
+	for key, values := range query {
+		for _, value := range values {
+			params = append(params, escape(key)+"="+escape(value))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// escape encodes all the characters except the RFC 3986 unreserved characters
+func escape(s string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(s) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+func hashHex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
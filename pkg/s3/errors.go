@@ -0,0 +1,39 @@
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// Error is returned for any non 2xx response,
+// it carries the error code and the message reported by the storage
+type Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("s3 error, status code: %d, %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("s3 error, status code: %d, code: %s, %s", e.StatusCode, e.Code, e.Message)
+}
+
+// newError composes Error from the response status code and body,
+// if the body is not a valid S3 error the raw body is used as the message
+func newError(statusCode int, body []byte) *Error {
+	e := &Error{}
+	if err := xml.Unmarshal(body, e); err != nil || (e.Code == "" && e.Message == "") {
+		e.Message = string(body)
+	}
+	e.StatusCode = statusCode
+	return e
+}
+
+// IsNotFound returns true if err is an S3 error for missing object or bucket
+func IsNotFound(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
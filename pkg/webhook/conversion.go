@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// conversionReview is the apiextensions.k8s.io/v1beta1 ConversionReview sent by the API server
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// rdbcConverter serves the CRD conversion webhook of the Rdbc between v1alpha1 and the v1beta1 storage version
+type rdbcConverter struct{}
+
+var _ http.Handler = &rdbcConverter{}
+
+// ServeHTTP converts the objects of the ConversionReview, a failed conversion is reported in the review result
+func (c *rdbcConverter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &conversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		log.Error(err, "Failed to decode the ConversionReview")
+		http.Error(w, "invalid ConversionReview", http.StatusBadRequest)
+		return
+	}
+	response := &conversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, object := range review.Request.Objects {
+		converted, err := convertRdbc(object.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			log.Error(err, "Failed to convert Rdbc")
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	review.Request = nil
+	review.Response = response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Error(err, "Failed to write the ConversionReview")
+	}
+}
+
+// convertRdbc converts the Rdbc JSON to the desired API version
+func convertRdbc(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	meta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, meta); err != nil {
+		return nil, err
+	}
+	if meta.Kind != "Rdbc" {
+		return nil, fmt.Errorf("unsupported kind: %s", meta.Kind)
+	}
+	alpha, beta := rdbcv1alpha1.SchemeGroupVersion.String(), rdbcv1beta1.SchemeGroupVersion.String()
+	switch {
+	case meta.APIVersion == desiredAPIVersion:
+		return raw, nil
+	case meta.APIVersion == alpha && desiredAPIVersion == beta:
+		in, out := &rdbcv1alpha1.Rdbc{}, &rdbcv1beta1.Rdbc{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		if err := rdbcv1alpha1.Convert_v1alpha1_Rdbc_To_v1beta1_Rdbc(in, out); err != nil {
+			return nil, err
+		}
+		return json.Marshal(out)
+	case meta.APIVersion == beta && desiredAPIVersion == alpha:
+		in, out := &rdbcv1beta1.Rdbc{}, &rdbcv1alpha1.Rdbc{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		if err := rdbcv1alpha1.Convert_v1beta1_Rdbc_To_v1alpha1_Rdbc(in, out); err != nil {
+			return nil, err
+		}
+		return json.Marshal(out)
+	}
+	return nil, fmt.Errorf("unsupported conversion of Rdbc from %s to %s", meta.APIVersion, desiredAPIVersion)
+}
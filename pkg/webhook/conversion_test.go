@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	alphaVersion = rdbcv1alpha1.SchemeGroupVersion.String()
+	betaVersion  = rdbcv1beta1.SchemeGroupVersion.String()
+)
+
+func marshal(t *testing.T, object interface{}) []byte {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return raw
+}
+
+func alphaRdbc(password string) *rdbcv1alpha1.Rdbc {
+	return &rdbcv1alpha1.Rdbc{
+		TypeMeta:   metav1.TypeMeta{Kind: "Rdbc", APIVersion: alphaVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       rdbcv1alpha1.RdbcSpec{Name: "db", Size: 100, Password: password},
+	}
+}
+
+func TestConvertRdbc(t *testing.T) {
+	tests := []struct {
+		name              string
+		object            interface{}
+		desiredAPIVersion string
+		wantErr           bool
+		check             func(t *testing.T, raw []byte)
+	}{
+		{
+			name:              "v1alpha1 to v1beta1",
+			object:            alphaRdbc("secret"),
+			desiredAPIVersion: betaVersion,
+			check: func(t *testing.T, raw []byte) {
+				out := &rdbcv1beta1.Rdbc{}
+				if err := json.Unmarshal(raw, out); err != nil {
+					t.Fatalf("json.Unmarshal() error = %v", err)
+				}
+				if out.APIVersion != betaVersion || out.Kind != "Rdbc" {
+					t.Errorf("converted to %s %s, want %s Rdbc", out.APIVersion, out.Kind, betaVersion)
+				}
+				if out.Annotations[rdbcv1beta1.PasswordAnnotation] != "secret" {
+					t.Errorf("annotations = %v, want the password in %s", out.Annotations, rdbcv1beta1.PasswordAnnotation)
+				}
+				if out.Spec.Size != 100 {
+					t.Errorf("spec.size = %d, want 100", out.Spec.Size)
+				}
+			},
+		},
+		{
+			name: "v1beta1 to v1alpha1",
+			object: &rdbcv1beta1.Rdbc{
+				TypeMeta: metav1.TypeMeta{Kind: "Rdbc", APIVersion: betaVersion},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "db",
+					Annotations: map[string]string{rdbcv1beta1.PasswordAnnotation: "secret"},
+				},
+				Status: rdbcv1beta1.RdbcStatus{
+					Conditions: []rdbcv1beta1.RdbcCondition{
+						{Type: rdbcv1beta1.RdbcConditionReady, Status: "True", Message: "db is active"},
+					},
+				},
+			},
+			desiredAPIVersion: alphaVersion,
+			check: func(t *testing.T, raw []byte) {
+				out := &rdbcv1alpha1.Rdbc{}
+				if err := json.Unmarshal(raw, out); err != nil {
+					t.Fatalf("json.Unmarshal() error = %v", err)
+				}
+				if out.APIVersion != alphaVersion {
+					t.Errorf("converted to %s, want %s", out.APIVersion, alphaVersion)
+				}
+				if out.Spec.Password != "secret" || out.Annotations != nil {
+					t.Errorf("spec.password = %q, annotations = %v, want the password moved back to the spec", out.Spec.Password, out.Annotations)
+				}
+				if out.Status.Message != "db is active" {
+					t.Errorf("status.message = %q, want the Ready condition message", out.Status.Message)
+				}
+			},
+		},
+		{
+			name:              "same version",
+			object:            alphaRdbc("secret"),
+			desiredAPIVersion: alphaVersion,
+			check: func(t *testing.T, raw []byte) {
+				out := &rdbcv1alpha1.Rdbc{}
+				if err := json.Unmarshal(raw, out); err != nil {
+					t.Fatalf("json.Unmarshal() error = %v", err)
+				}
+				if out.Spec.Password != "secret" {
+					t.Errorf("spec.password = %q, want the object unchanged", out.Spec.Password)
+				}
+			},
+		},
+		{
+			name:              "unsupported kind",
+			object:            &metav1.TypeMeta{Kind: "RdbcBackup", APIVersion: alphaVersion},
+			desiredAPIVersion: betaVersion,
+			wantErr:           true,
+		},
+		{
+			name:              "unsupported version",
+			object:            alphaRdbc(""),
+			desiredAPIVersion: "rdbc.cnative/v2",
+			wantErr:           true,
+		},
+		{
+			name:              "malformed object",
+			object:            map[string]interface{}{"kind": "Rdbc", "apiVersion": alphaVersion, "spec": map[string]interface{}{"size": "large"}},
+			desiredAPIVersion: betaVersion,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := convertRdbc(marshal(t, tt.object), tt.desiredAPIVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convertRdbc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, raw)
+			}
+		})
+	}
+}
+
+func TestRdbcConverterServeHTTP(t *testing.T) {
+	tests := []struct {
+		name              string
+		objects           []interface{}
+		desiredAPIVersion string
+		wantStatus        string
+		wantConverted     int
+	}{
+		{
+			name:              "converts every object",
+			objects:           []interface{}{alphaRdbc("secret"), alphaRdbc("")},
+			desiredAPIVersion: betaVersion,
+			wantStatus:        metav1.StatusSuccess,
+			wantConverted:     2,
+		},
+		{
+			name:              "no objects",
+			desiredAPIVersion: betaVersion,
+			wantStatus:        metav1.StatusSuccess,
+		},
+		{
+			name:              "a failed object fails the review",
+			objects:           []interface{}{alphaRdbc("secret"), &metav1.TypeMeta{Kind: "RdbcBackup", APIVersion: alphaVersion}},
+			desiredAPIVersion: betaVersion,
+			wantStatus:        metav1.StatusFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &conversionRequest{UID: "705ab4f5-6393-11e8-b7cc-42010a800002", DesiredAPIVersion: tt.desiredAPIVersion}
+			for _, object := range tt.objects {
+				request.Objects = append(request.Objects, runtime.RawExtension{Raw: marshal(t, object)})
+			}
+			review := &conversionReview{
+				TypeMeta: metav1.TypeMeta{Kind: "ConversionReview", APIVersion: "apiextensions.k8s.io/v1beta1"},
+				Request:  request,
+			}
+			recorder := httptest.NewRecorder()
+			(&rdbcConverter{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(marshal(t, review))))
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status code = %d, want %d", recorder.Code, http.StatusOK)
+			}
+			got := &conversionReview{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got.Kind != "ConversionReview" || got.APIVersion != "apiextensions.k8s.io/v1beta1" {
+				t.Errorf("response is a %s %s, want the ConversionReview type", got.APIVersion, got.Kind)
+			}
+			if got.Request != nil || got.Response == nil {
+				t.Fatalf("request = %v, response = %v, want the response only", got.Request, got.Response)
+			}
+			if got.Response.UID != request.UID {
+				t.Errorf("uid = %s, want %s", got.Response.UID, request.UID)
+			}
+			if got.Response.Result.Status != tt.wantStatus {
+				t.Errorf("result = %+v, want %s", got.Response.Result, tt.wantStatus)
+			}
+			if tt.wantStatus == metav1.StatusFailure && got.Response.Result.Message == "" {
+				t.Errorf("the failure result has no message")
+			}
+			if len(got.Response.ConvertedObjects) != tt.wantConverted {
+				t.Errorf("%d objects converted, want %d", len(got.Response.ConvertedObjects), tt.wantConverted)
+			}
+		})
+	}
+}
+
+func TestRdbcConverterServeHTTPInvalidReview(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "not json", body: "convert"},
+		{name: "no request", body: `{"kind":"ConversionReview","apiVersion":"apiextensions.k8s.io/v1beta1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			(&rdbcConverter{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewBufferString(tt.body)))
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("status code = %d, want %d", recorder.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
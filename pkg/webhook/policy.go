@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// Max db size in Megabytes the Rdbcs may request, not limited if not set
+	MaxDbSize = "MAX_DB_SIZE"
+
+	// Max shards count the Rdbcs may request, defaults to the Redis Enterprise limit
+	MaxDbShards = "MAX_DB_SHARDS"
+)
+
+// policy is the operator wide limits of the Rdbcs
+type policy struct {
+	// maxSize is the max db size in Megabytes, 0 for no limit
+	maxSize int
+	// maxShards is the max shards count
+	maxShards int
+}
+
+// policyFromEnv returns the operator policy set by the env vars
+func policyFromEnv() (*policy, error) {
+	p := &policy{maxShards: rdbcv1beta1.MaxShardsCount}
+	if value := os.Getenv(MaxDbSize); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("%s must be a positive number of Megabytes, got: %s", MaxDbSize, value)
+		}
+		p.maxSize = size
+	}
+	if value := os.Getenv(MaxDbShards); value != "" {
+		shards, err := strconv.Atoi(value)
+		if err != nil || shards < 1 || shards > rdbcv1beta1.MaxShardsCount {
+			return nil, fmt.Errorf("%s must be between 1 and %d, got: %s", MaxDbShards, rdbcv1beta1.MaxShardsCount, value)
+		}
+		p.maxShards = shards
+	}
+	return p, nil
+}
+
+// validate checks the defaulted spec against the policy limits
+func (p *policy) validate(spec *rdbcv1beta1.RdbcSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.maxSize > 0 && spec.Size > p.maxSize {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), spec.Size, fmt.Sprintf("exceeds the operator limit of %d Megabytes", p.maxSize)))
+	}
+	if spec.ShardsCount > p.maxShards {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("shardsCount"), spec.ShardsCount, fmt.Sprintf("exceeds the operator limit of %d shards", p.maxShards)))
+	}
+	return allErrs
+}
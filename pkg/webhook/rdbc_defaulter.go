@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// rdbcDefaulter sets the defaults of the unset Rdbc spec fields on create and update,
+// thus the applied Rdbc shows the db the operator provisions
+type rdbcDefaulter struct {
+	decoder atypes.Decoder
+}
+
+var _ admission.Handler = &rdbcDefaulter{}
+
+// Handle patches the Rdbc spec with the same defaults the controller applies
+func (d *rdbcDefaulter) Handle(ctx context.Context, req atypes.Request) atypes.Response {
+	rdbc := &rdbcv1beta1.Rdbc{}
+	if err := d.decoder.Decode(req, rdbc); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	if req.AdmissionRequest.Operation == admissionv1beta1.Update {
+		old := &rdbcv1beta1.Rdbc{}
+		if err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, old); err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		// The metadata and the status updates don't change the spec, e.g. the finalizer removal of the Rdbc created before the webhook
+		if reflect.DeepEqual(rdbc.Spec, old.Spec) {
+			return admission.ValidationResponse(true, "")
+		}
+	}
+	spec := rdbc.Spec.DeepCopy()
+	rdbcv1beta1.SetDefaults_RdbcSpec(spec)
+	// The spec is patched against the request object as is, thus the false booleans, e.g. replication, are shown as well
+	original := &unstructured.Unstructured{}
+	if err := original.UnmarshalJSON(req.AdmissionRequest.Object.Raw); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	defaultedSpec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	defaulted := original.DeepCopy()
+	defaulted.Object["spec"] = defaultedSpec
+	return admission.PatchResponse(original, defaulted)
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	rdbcv1alpha1 "github.com/rdbc-operator/pkg/apis/rdbc/v1alpha1"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	"github.com/rdbc-operator/pkg/quota"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// rdbcValidator rejects the invalid Rdbcs on create and update
+type rdbcValidator struct {
+	client  client.Client
+	decoder atypes.Decoder
+	policy  *policy
+}
+
+var _ admission.Handler = &rdbcValidator{}
+
+// Handle validates the Rdbc spec, the policy limits, the namespace quotas, the db name uniqueness
+// and, once the db is created, the immutable fields
+func (v *rdbcValidator) Handle(ctx context.Context, req atypes.Request) atypes.Response {
+	rdbc := &rdbcv1beta1.Rdbc{}
+	if err := v.decoder.Decode(req, rdbc); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	var old *rdbcv1beta1.Rdbc
+	if req.AdmissionRequest.Operation == admissionv1beta1.Update {
+		old = &rdbcv1beta1.Rdbc{}
+		if err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, old); err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		// The metadata and the status updates are allowed, e.g. the finalizer removal of the Rdbc created before the webhook
+		if reflect.DeepEqual(rdbc.Spec, old.Spec) {
+			return admission.ValidationResponse(true, "")
+		}
+	}
+	allErrs, err := v.validate(ctx, rdbc, old)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	if len(allErrs) > 0 {
+		return denied(allErrs.ToAggregate().Error())
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+func (v *rdbcValidator) validate(ctx context.Context, rdbc *rdbcv1beta1.Rdbc, old *rdbcv1beta1.Rdbc) (field.ErrorList, error) {
+	fldPath := field.NewPath("spec")
+	spec := defaultedSpec(rdbc)
+	allErrs := rdbcv1beta1.ValidateRdbcSpec(spec, fldPath)
+	allErrs = append(allErrs, v.policy.validate(spec, fldPath)...)
+	// The v1alpha1 spec.password is converted to the password annotation, the migrated v1alpha1 manifests
+	// set it again on kubectl apply, thus the password of the passwordSecretRef Secret is accepted
+	if password, ok := rdbc.Annotations[rdbcv1beta1.PasswordAnnotation]; ok && spec.PasswordSecretRef != nil {
+		migrated, err := v.isSecretPassword(ctx, rdbc.Namespace, spec.PasswordSecretRef, password)
+		if err != nil {
+			return nil, err
+		}
+		if !migrated {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("password"),
+				"may not be set together with passwordSecretRef, unless it's the password of the passwordSecretRef Secret"))
+		}
+	}
+	// The changes which can't be applied in place are rejected once the db exists
+	if old != nil && old.Status.DbUid != 0 {
+		allErrs = append(allErrs, rdbcv1beta1.ValidateRdbcSpecUpdate(spec, defaultedSpec(old), fldPath)...)
+	}
+	if old == nil || spec.Name != old.Spec.Name || spec.ClusterRef != old.Spec.ClusterRef {
+		conflict, err := v.conflictingRdbc(ctx, rdbc)
+		if err != nil {
+			return nil, err
+		}
+		if conflict != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), spec.Name, fmt.Sprintf("is already requested by Rdbc %s on the same cluster", conflict)))
+		}
+	}
+	// The quotas count all the admitted Rdbcs, the shrinking updates are allowed over the quota
+	current := rdbcv1alpha1.RdbcQuotaUsage{}
+	if old != nil {
+		current = quota.Usage(defaultedSpec(old))
+	}
+	exceeded, err := quota.Check(ctx, v.client, rdbc, quota.Usage(spec), current, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range exceeded {
+		allErrs = append(allErrs, field.Forbidden(fldPath, message))
+	}
+	return allErrs, nil
+}
+
+// denied returns the response which rejects the request, the message is shown to the user by kubectl
+func denied(message string) atypes.Response {
+	return atypes.Response{
+		Response: &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Code:    http.StatusUnprocessableEntity,
+				Reason:  metav1.StatusReasonInvalid,
+				Message: message,
+			},
+		},
+	}
+}
+
+// conflictingRdbc returns the namespace/name of another Rdbc which requests the same db name on the same cluster,
+// empty if there is none. The Rdbcs without clusterRef are considered to target the same default cluster.
+func (v *rdbcValidator) conflictingRdbc(ctx context.Context, rdbc *rdbcv1beta1.Rdbc) (string, error) {
+	rdbcs := &rdbcv1beta1.RdbcList{}
+	if err := v.client.List(ctx, &client.ListOptions{}, rdbcs); err != nil {
+		return "", err
+	}
+	for _, other := range rdbcs.Items {
+		if other.Namespace == rdbc.Namespace && other.Name == rdbc.Name {
+			continue
+		}
+		if other.Spec.Name == rdbc.Spec.Name && dbCluster(&other) == dbCluster(rdbc) {
+			return fmt.Sprintf("%s/%s", other.Namespace, other.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// isSecretPassword returns true if the password is the value of the Secret key, false if the Secret or the key doesn't exist
+func (v *rdbcValidator) isSecretPassword(ctx context.Context, namespace string, ref *corev1.SecretKeySelector, password string) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	value, ok := secret.Data[ref.Key]
+	return ok && string(value) == password, nil
+}
+
+// dbCluster returns the cluster the db was created on, or the cluster it's going to be created on
+func dbCluster(rdbc *rdbcv1beta1.Rdbc) string {
+	if rdbc.Status.DbUid != 0 {
+		return rdbc.Status.Cluster
+	}
+	return rdbc.Spec.ClusterRef
+}
+
+// defaultedSpec returns the spec with the defaults of the unset fields, same as the controller applies
+func defaultedSpec(rdbc *rdbcv1beta1.Rdbc) *rdbcv1beta1.RdbcSpec {
+	spec := rdbc.Spec.DeepCopy()
+	rdbcv1beta1.SetDefaults_RdbcSpec(spec)
+	return spec
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rdbc-operator/pkg/apis"
+	rdbcv1beta1 "github.com/rdbc-operator/pkg/apis/rdbc/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRdbcValidatorMigratedPassword(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-password", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	tests := []struct {
+		name     string
+		password string
+		ref      *corev1.SecretKeySelector
+		wantErrs int
+	}{
+		{
+			name:     "the password of the Secret",
+			password: "secret",
+			ref:      &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db-password"}, Key: "password"},
+		},
+		{
+			name:     "another password",
+			password: "other",
+			ref:      &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db-password"}, Key: "password"},
+			wantErrs: 1,
+		},
+		{
+			name:     "missing key",
+			password: "secret",
+			ref:      &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db-password"}, Key: "pass"},
+			wantErrs: 1,
+		},
+		{
+			name:     "missing Secret",
+			password: "secret",
+			ref:      &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}, Key: "password"},
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := corev1.AddToScheme(s); err != nil {
+				t.Fatalf("failed to register core/v1: %v", err)
+			}
+			if err := apis.AddToScheme(s); err != nil {
+				t.Fatalf("failed to register the Rdbc APIs: %v", err)
+			}
+			v := &rdbcValidator{
+				client: fakeclient.NewFakeClientWithScheme(s, secret),
+				policy: &policy{maxShards: rdbcv1beta1.MaxShardsCount},
+			}
+			rdbc := &rdbcv1beta1.Rdbc{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "db",
+					Namespace:   "default",
+					Annotations: map[string]string{rdbcv1beta1.PasswordAnnotation: tt.password},
+				},
+				Spec: rdbcv1beta1.RdbcSpec{Name: "db", Size: 100, PasswordSecretRef: tt.ref},
+			}
+			allErrs, err := v.validate(context.TODO(), rdbc, nil)
+			if err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			if len(allErrs) != tt.wantErrs {
+				t.Errorf("validate() = %v, want %d errors", allErrs, tt.wantErrs)
+			}
+		})
+	}
+}
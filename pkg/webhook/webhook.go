@@ -0,0 +1,102 @@
+// Package webhook serves the admission webhooks of the operator resources and the Rdbc conversion webhook,
+// the webhooks are served by the operator manager over TLS
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+)
+
+var log = logf.Log.WithName("webhook")
+
+const (
+	// Port the webhooks are served on, defaults to 9443
+	WebhookPort = "WEBHOOK_PORT"
+
+	// Directory of the webhook certificate (tls.crt) and key (tls.key), the webhooks are not served if the certificate is missing
+	WebhookCertDir = "WEBHOOK_CERT_DIR"
+
+	defaultWebhookPort    = 9443
+	defaultWebhookCertDir = "/etc/webhook/certs"
+
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+
+	// Path of the Rdbc conversion webhook
+	convertRdbcPath = "/convert-rdbc"
+	// Path of the Rdbc defaulting webhook
+	mutateRdbcPath = "/mutate-rdbc"
+	// Path of the Rdbc validating webhook
+	validateRdbcPath = "/validate-rdbc"
+)
+
+// AddToManager adds the webhook server to the Manager
+func AddToManager(mgr manager.Manager) error {
+	port := defaultWebhookPort
+	if value := os.Getenv(WebhookPort); value != "" {
+		p, err := strconv.Atoi(value)
+		if err != nil || p <= 0 || p > 65535 {
+			return fmt.Errorf("%s must be a port number, got: %s", WebhookPort, value)
+		}
+		port = p
+	}
+	certDir := os.Getenv(WebhookCertDir)
+	if certDir == "" {
+		certDir = defaultWebhookCertDir
+	}
+	certPath, keyPath := filepath.Join(certDir, certFile), filepath.Join(certDir, keyFile)
+	if _, err := os.Stat(certPath); err != nil {
+		// The operator runs without the webhooks, e.g. while debugging locally, the specs are still validated on reconcile
+		log.Info(fmt.Sprintf("webhook certificate %s not found, the webhooks are disabled", certPath))
+		return nil
+	}
+
+	policy, err := policyFromEnv()
+	if err != nil {
+		return err
+	}
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	mutateRdbc := &admission.Webhook{
+		Name:     "default.rdbc.cnative",
+		Type:     types.WebhookTypeMutating,
+		Path:     mutateRdbcPath,
+		Handlers: []admission.Handler{&rdbcDefaulter{decoder: decoder}},
+	}
+	validateRdbc := &admission.Webhook{
+		Name:     "validate.rdbc.cnative",
+		Type:     types.WebhookTypeValidating,
+		Path:     validateRdbcPath,
+		Handlers: []admission.Handler{&rdbcValidator{client: mgr.GetClient(), decoder: decoder, policy: policy}},
+	}
+	mux := http.NewServeMux()
+	mux.Handle(convertRdbcPath, &rdbcConverter{})
+	mux.Handle(mutateRdbc.Path, mutateRdbc)
+	mux.Handle(validateRdbc.Path, validateRdbc)
+
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux}
+		go func() {
+			<-stop
+			if err := server.Shutdown(context.Background()); err != nil {
+				log.Error(err, "Failed to shut down the webhook server")
+			}
+		}()
+		log.Info(fmt.Sprintf("serving the webhooks on port %d", port))
+		if err := server.ListenAndServeTLS(certPath, keyPath); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}))
+}